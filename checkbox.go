@@ -0,0 +1,192 @@
+package fynetree
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+	"fyne.io/fyne/widget"
+)
+
+// CheckState is the state of a node's checkbox.
+type CheckState int
+
+const (
+	// Unchecked means neither the node nor any of its descendants are checked.
+	Unchecked CheckState = iota
+	// Checked means the node and all of its descendants are checked.
+	Checked
+	// Indeterminate means some, but not all, of the node's descendants are checked.
+	Indeterminate
+)
+
+// CheckChangedHandler is called when a node is checked or unchecked, with the node's new state.
+type CheckChangedHandler func(node *TreeNode, state CheckState)
+
+var indeterminateCheckIcon = theme.NewThemedResource(fyne.NewStaticResource("indeterminate-check-box.svg", []byte(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">`+
+		`<path d="M19 3H5c-1.1 0-2 .9-2 2v14c0 1.1.9 2 2 2h14c1.1 0 2-.9 2-2V5c0-1.1-.9-2-2-2zm-2 10H7v-2h10v2z"/>`+
+		`</svg>`)), nil)
+
+// GetCheckState returns the state of this node's checkbox.
+func (n *TreeNode) GetCheckState() CheckState {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.checkState
+}
+
+// IsChecked returns whether this node's checkbox is fully checked.
+func (n *TreeNode) IsChecked() bool {
+	return n.GetCheckState() == Checked
+}
+
+// SetChecked checks or unchecks this node and all of its descendants, and updates the state of its ancestors.
+// The container's OnCheckChanged handler is called for each node whose state changed, starting with this node and its
+// descendants, then its ancestors.
+func (n *TreeNode) SetChecked(checked bool) {
+	state := Unchecked
+	if checked {
+		state = Checked
+	}
+	changed := n.setSubtreeCheckState(state)
+	if parent := n.parent; parent != nil {
+		changed = append(changed, parent.updateCheckState()...)
+	}
+	n.Refresh()
+	n.checkChanged(changed)
+}
+
+// ToggleChecked unchecks a checked node, and checks an unchecked or indeterminate node.
+func (n *TreeNode) ToggleChecked() {
+	n.SetChecked(!n.IsChecked())
+}
+
+// setCheckState changes the node's state, and returns whether it changed.
+func (n *TreeNode) setCheckState(state CheckState) bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	changed := n.checkState != state
+	n.checkState = state
+	return changed
+}
+
+// setSubtreeCheckState changes the state of this node and its descendants, and returns the nodes that changed.
+func (n *TreeNode) setSubtreeCheckState(state CheckState) []*TreeNode {
+	var changed []*TreeNode
+	_ = n.Walk(PreOrder, func(node *TreeNode) error {
+		if node.setCheckState(state) && !node.IsPlaceholder() {
+			changed = append(changed, node)
+		}
+		return nil
+	})
+	return changed
+}
+
+// checkChanged calls the container's OnCheckChanged handler for each of the nodes.
+func (n *TreeNode) checkChanged(changed []*TreeNode) {
+	c := n.treeContainer()
+	if c == nil || c.OnCheckChanged == nil {
+		return
+	}
+	for _, node := range changed {
+		c.OnCheckChanged(node, node.GetCheckState())
+	}
+}
+
+// updateCheckState derives this node's state from its children, then updates its ancestors in turn, and returns the
+// nodes that changed. A node without children keeps its own state, unless it was indeterminate.
+func (n *TreeNode) updateCheckState() []*TreeNode {
+	var changed []*TreeNode
+	for node := n; node != nil; node = node.parent {
+		var total, checked, unchecked int
+		for _, child := range node.Children() {
+			if child.IsPlaceholder() {
+				continue
+			}
+			total++
+			switch child.GetCheckState() {
+			case Checked:
+				checked++
+			case Unchecked:
+				unchecked++
+			}
+		}
+		state := Indeterminate
+		if total == 0 {
+			if state = node.GetCheckState(); state == Indeterminate {
+				state = Unchecked
+			}
+		} else if checked == total {
+			state = Checked
+		} else if unchecked == total {
+			state = Unchecked
+		}
+		if !node.setCheckState(state) {
+			break
+		}
+		changed = append(changed, node)
+	}
+	return changed
+}
+
+// childAdded updates the check state after a child is added. Children added to a checked node, such as lazily loaded
+// children, are checked along with it so it stays checked.
+func (n *TreeNode) childAdded(child *TreeNode) {
+	if child.IsPlaceholder() {
+		return
+	}
+	if n.GetCheckState() == Checked {
+		n.checkChanged(child.setSubtreeCheckState(Checked))
+		return
+	}
+	if n.GetCheckState() == Unchecked && child.GetCheckState() == Unchecked {
+		return
+	}
+	n.checkChanged(n.updateCheckState())
+}
+
+// CheckedNodes returns every checked node in pre-order. Indeterminate nodes aren't included.
+func (t *TreeContainer) CheckedNodes() []*TreeNode {
+	return t.FindAll(func(node *TreeNode) bool {
+		return node.IsChecked()
+	})
+}
+
+var _ fyne.Tappable = (*nodeCheck)(nil)
+
+// nodeCheck shows a node's check state in its row when its container has checkboxes enabled.
+type nodeCheck struct {
+	widget.Icon
+
+	node *TreeNode
+}
+
+func newNodeCheck(node *TreeNode) *nodeCheck {
+	check := &nodeCheck{
+		node: node,
+	}
+	check.ExtendBaseWidget(check)
+	check.Refresh()
+	return check
+}
+
+func (c *nodeCheck) Refresh() {
+	if c.node == nil {
+		return
+	}
+	if container := c.node.treeContainer(); container == nil || !container.Checkboxes || c.node.IsPlaceholder() {
+		c.Hide()
+		return
+	}
+	c.Show()
+	switch c.node.GetCheckState() {
+	case Checked:
+		c.SetResource(theme.CheckButtonCheckedIcon())
+	case Indeterminate:
+		c.SetResource(indeterminateCheckIcon)
+	default:
+		c.SetResource(theme.CheckButtonIcon())
+	}
+}
+
+func (c *nodeCheck) Tapped(_ *fyne.PointEvent) {
+	c.node.ToggleChecked()
+}
@@ -0,0 +1,124 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"fyne.io/fyne/theme"
+)
+
+// checkSetup builds root -> (A -> (C, D), B) in a container with checkboxes.
+func checkSetup() {
+	traversalSetup()
+	treeContainer.Checkboxes = true
+}
+
+func assertCheckStates(t *testing.T, want map[*TreeNode]CheckState) {
+	t.Helper()
+	for node, state := range want {
+		if got := node.GetCheckState(); got != state {
+			t.Fatalf("Expected node '%s' to have state %d, got %d", node.GetModelText(), state, got)
+		}
+	}
+}
+
+func TestTreeNode_SetCheckedPropagates(t *testing.T) {
+	checkSetup()
+	var changed []*TreeNode
+	treeContainer.OnCheckChanged = func(node *TreeNode, _ CheckState) {
+		changed = append(changed, node)
+	}
+
+	nodeA.SetChecked(true)
+	assertCheckStates(t, map[*TreeNode]CheckState{
+		rootNode: Indeterminate, nodeA: Checked, nodeB: Unchecked, nodeC: Checked, nodeD: Checked,
+	})
+	if len(changed) != 4 || changed[0] != nodeA || changed[1] != nodeC || changed[3] != rootNode {
+		t.Fatalf("Expected OnCheckChanged for the node, its descendants and its parent, got %d calls", len(changed))
+	}
+
+	nodeD.SetChecked(false)
+	assertCheckStates(t, map[*TreeNode]CheckState{
+		rootNode: Indeterminate, nodeA: Indeterminate, nodeC: Checked, nodeD: Unchecked,
+	})
+
+	nodeD.ToggleChecked()
+	nodeB.ToggleChecked()
+	assertCheckStates(t, map[*TreeNode]CheckState{rootNode: Checked, nodeA: Checked})
+
+	changed = nil
+	rootNode.SetChecked(false)
+	assertCheckStates(t, map[*TreeNode]CheckState{
+		rootNode: Unchecked, nodeA: Unchecked, nodeB: Unchecked, nodeC: Unchecked, nodeD: Unchecked,
+	})
+	if len(changed) != 5 || changed[0] != rootNode {
+		t.Fatalf("Expected OnCheckChanged once per changed node, got %d calls", len(changed))
+	}
+}
+
+func TestTreeContainer_CheckedNodes(t *testing.T) {
+	checkSetup()
+	nodeC.SetChecked(true)
+	nodeB.SetChecked(true)
+	checked := treeContainer.CheckedNodes()
+	if len(checked) != 2 || checked[0] != nodeC || checked[1] != nodeB {
+		t.Fatalf("Expected C and B to be checked")
+	}
+}
+
+func TestTreeNode_CheckStateOnAddRemove(t *testing.T) {
+	checkSetup()
+	nodeA.SetChecked(true)
+	added := NewLeafTreeNode(NewStaticModel(nil, "E"))
+	_ = nodeA.Append(added)
+	assertCheckStates(t, map[*TreeNode]CheckState{nodeA: Checked, added: Checked})
+
+	nodeC.SetChecked(false)
+	assertCheckStates(t, map[*TreeNode]CheckState{nodeA: Indeterminate})
+	_, _ = nodeA.Remove(nodeC)
+	assertCheckStates(t, map[*TreeNode]CheckState{nodeA: Checked, rootNode: Indeterminate})
+
+	nodeD.SetChecked(false)
+	_, _ = nodeA.Remove(nodeD)
+	_, _ = nodeA.Remove(added)
+	assertCheckStates(t, map[*TreeNode]CheckState{nodeA: Checked})
+	partial := NewTreeNode(NewStaticModel(nil, "F"))
+	_ = partial.Append(NewLeafTreeNode(NewStaticModel(nil, "G")))
+	_ = partial.Append(added)
+	_ = nodeA.Append(partial)
+	added.SetChecked(false)
+	assertCheckStates(t, map[*TreeNode]CheckState{nodeA: Indeterminate, partial: Indeterminate})
+	_, _ = nodeA.Remove(partial)
+	assertCheckStates(t, map[*TreeNode]CheckState{nodeA: Unchecked})
+}
+
+func TestTreeContainer_SpaceTogglesCheck(t *testing.T) {
+	checkSetup()
+	treeContainer.FocusNode(nodeB)
+	treeContainer.TypedKey(&fyne.KeyEvent{Name: fyne.KeySpace})
+	if !nodeB.IsChecked() {
+		t.Fatalf("Expected space to check the focused node")
+	}
+}
+
+func TestTreeRow_ShowsCheckbox(t *testing.T) {
+	checkSetup()
+	rootNode.Expand()
+	nodeB.SetChecked(true)
+	w := showContainer()
+	defer w.Close()
+
+	for _, node := range []*TreeNode{rootNode, nodeA, nodeB} {
+		if rowPart(treeContainer, node, RowPartCheck) == nil {
+			t.Fatalf("Expected a checkbox to be shown for '%s'", node.GetModelText())
+		}
+	}
+	if check := rowPart(treeContainer, nodeB, RowPartCheck).(*nodeCheck); check.Resource != theme.CheckButtonCheckedIcon() {
+		t.Fatalf("Expected checked icon for B")
+	}
+	test.Tap(rowPart(treeContainer, nodeA, RowPartCheck).(fyne.Tappable))
+	if !nodeA.IsChecked() || rootNode.GetCheckState() != Checked {
+		t.Fatalf("Expected tapping the checkbox to check A and complete the root")
+	}
+}
@@ -0,0 +1,259 @@
+package fynetree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"fyne.io/fyne"
+)
+
+// ModelFactory creates an empty TreeNodeModel that will have a node's JSON model data unmarshalled into it.
+type ModelFactory func() TreeNodeModel
+
+// ModelRegistry maps TreeNodeModel types to the names they're stored under in JSON, so trees can be reconstructed with
+// the right model types. Models are marshalled and unmarshalled with encoding/json, so they can customize how they're
+// stored with struct tags or by implementing json.Marshaler and json.Unmarshaler.
+type ModelRegistry struct {
+	mux       sync.Mutex
+	factories map[string]ModelFactory
+	names     map[reflect.Type]string
+}
+
+// DefaultModelRegistry is used by the package level codec functions, and has StaticNodeModel registered as "static".
+var DefaultModelRegistry = NewModelRegistry()
+
+func init() {
+	DefaultModelRegistry.Register("static", func() TreeNodeModel { return &StaticNodeModel{} })
+}
+
+// NewModelRegistry creates an empty registry.
+func NewModelRegistry() *ModelRegistry {
+	return &ModelRegistry{
+		factories: map[string]ModelFactory{},
+		names:     map[reflect.Type]string{},
+	}
+}
+
+// Register stores models created by the factory under the given name. Registering a name again replaces it.
+func (r *ModelRegistry) Register(name string, factory ModelFactory) {
+	if factory == nil {
+		panic("Can't register nil model factory")
+	}
+	model := factory()
+	if model == nil {
+		panic("Model factory returned nil")
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.factories[name] = factory
+	r.names[reflect.TypeOf(model)] = name
+}
+
+// RegisterModel registers a model type with the DefaultModelRegistry.
+func RegisterModel(name string, factory ModelFactory) {
+	DefaultModelRegistry.Register(name, factory)
+}
+
+type nodeJSON struct {
+	Type     string          `json:"type"`
+	Model    json.RawMessage `json:"model"`
+	Leaf     bool            `json:"leaf"`
+	Expanded bool            `json:"expanded,omitempty"`
+	Children []*nodeJSON     `json:"children,omitempty"`
+}
+
+type treeJSON struct {
+	Roots []*nodeJSON `json:"roots"`
+}
+
+// MarshalNode writes the node and all of its descendants as JSON. Placeholder nodes are skipped, and the children of
+// ChildrenProvider models aren't written since they're loaded again when the node is expanded.
+func (r *ModelRegistry) MarshalNode(node *TreeNode) ([]byte, error) {
+	encoded, err := r.encodeNode(node)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalNode reconstructs a node and its descendants from JSON written by MarshalNode.
+func (r *ModelRegistry) UnmarshalNode(data []byte) (*TreeNode, error) {
+	encoded := &nodeJSON{}
+	if err := json.Unmarshal(data, encoded); err != nil {
+		return nil, err
+	}
+	return r.decodeNode(encoded)
+}
+
+// MarshalTree writes every root node in the container as JSON.
+func (r *ModelRegistry) MarshalTree(container *TreeContainer) ([]byte, error) {
+	tree := &treeJSON{Roots: []*nodeJSON{}}
+	for _, root := range container.Children() {
+		if root.IsPlaceholder() {
+			continue
+		}
+		encoded, err := r.encodeNode(root)
+		if err != nil {
+			return nil, err
+		}
+		tree.Roots = append(tree.Roots, encoded)
+	}
+	return json.Marshal(tree)
+}
+
+// UnmarshalTree reconstructs the root nodes from JSON written by MarshalTree, and appends them to the container.
+// Nothing is appended if any node fails to load.
+func (r *ModelRegistry) UnmarshalTree(data []byte, container *TreeContainer) error {
+	tree := &treeJSON{}
+	if err := json.Unmarshal(data, tree); err != nil {
+		return err
+	}
+	roots := make([]*TreeNode, 0, len(tree.Roots))
+	for _, encoded := range tree.Roots {
+		root, err := r.decodeNode(encoded)
+		if err != nil {
+			return err
+		}
+		roots = append(roots, root)
+	}
+	for _, root := range roots {
+		if err := container.Append(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ModelRegistry) encodeNode(node *TreeNode) (*nodeJSON, error) {
+	if node == nil {
+		return nil, errors.New("unable to marshal nil node")
+	}
+	r.mux.Lock()
+	name, ok := r.names[reflect.TypeOf(node.model)]
+	r.mux.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("model type %T is not registered", node.model)
+	}
+	model, err := json.Marshal(node.model)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s model: %w", name, err)
+	}
+	encoded := &nodeJSON{
+		Type:     name,
+		Model:    model,
+		Leaf:     node.IsLeaf(),
+		Expanded: node.IsExpanded(),
+	}
+	if _, lazy := node.model.(ChildrenProvider); lazy {
+		return encoded, nil
+	}
+	for _, obj := range node.nodeList.Objects {
+		child, ok := obj.(*TreeNode)
+		if !ok || child.IsPlaceholder() {
+			continue
+		}
+		encodedChild, err := r.encodeNode(child)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Children = append(encoded.Children, encodedChild)
+	}
+	return encoded, nil
+}
+
+func (r *ModelRegistry) decodeNode(encoded *nodeJSON) (*TreeNode, error) {
+	if encoded == nil {
+		return nil, errors.New("unable to unmarshal null node")
+	}
+	r.mux.Lock()
+	factory, ok := r.factories[encoded.Type]
+	r.mux.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("model type '%s' is not registered", encoded.Type)
+	}
+	model := factory()
+	if len(encoded.Model) > 0 {
+		if err := json.Unmarshal(encoded.Model, model); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal %s model: %w", encoded.Type, err)
+		}
+	}
+
+	node := NewTreeNode(model)
+	if encoded.Leaf {
+		node.SetLeaf()
+		return node, nil
+	}
+	node.SetBranch()
+	for _, encodedChild := range encoded.Children {
+		child, err := r.decodeNode(encodedChild)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.Append(child); err != nil {
+			return nil, err
+		}
+	}
+	if encoded.Expanded {
+		node.Expand()
+	}
+	return node, nil
+}
+
+// MarshalNode writes the node and its descendants as JSON using the DefaultModelRegistry.
+func MarshalNode(node *TreeNode) ([]byte, error) {
+	return DefaultModelRegistry.MarshalNode(node)
+}
+
+// UnmarshalNode reconstructs a node and its descendants using the DefaultModelRegistry.
+func UnmarshalNode(data []byte) (*TreeNode, error) {
+	return DefaultModelRegistry.UnmarshalNode(data)
+}
+
+// MarshalTree writes the container's root nodes as JSON using the DefaultModelRegistry.
+func MarshalTree(container *TreeContainer) ([]byte, error) {
+	return DefaultModelRegistry.MarshalTree(container)
+}
+
+// UnmarshalTree reconstructs root nodes using the DefaultModelRegistry, and appends them to the container.
+func UnmarshalTree(data []byte, container *TreeContainer) error {
+	return DefaultModelRegistry.UnmarshalTree(data, container)
+}
+
+type staticModelJSON struct {
+	Text     string        `json:"text,omitempty"`
+	Resource *resourceJSON `json:"resource,omitempty"`
+}
+
+type resourceJSON struct {
+	Name    string `json:"name"`
+	Content []byte `json:"content"`
+}
+
+// MarshalJSON writes the model's text, and its icon's name and content.
+func (s *StaticNodeModel) MarshalJSON() ([]byte, error) {
+	encoded := staticModelJSON{Text: s.Text}
+	if s.Resource != nil {
+		encoded.Resource = &resourceJSON{
+			Name:    s.Resource.Name(),
+			Content: s.Resource.Content(),
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON reads the model's text and icon. The icon is restored as a fyne.StaticResource.
+func (s *StaticNodeModel) UnmarshalJSON(data []byte) error {
+	encoded := staticModelJSON{}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	s.Text = encoded.Text
+	s.Resource = nil
+	if encoded.Resource != nil {
+		s.Resource = fyne.NewStaticResource(encoded.Resource.Name, encoded.Resource.Content)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package fynetree
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+)
+
+type codecTestModel struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	node  *TreeNode
+}
+
+func (c *codecTestModel) GetIconResource() fyne.Resource {
+	return nil
+}
+
+func (c *codecTestModel) GetText() string {
+	return c.Name
+}
+
+func (c *codecTestModel) SetTreeNode(node *TreeNode) {
+	c.node = node
+}
+
+func codecRegistry() *ModelRegistry {
+	registry := NewModelRegistry()
+	registry.Register("static", func() TreeNodeModel { return &StaticNodeModel{} })
+	registry.Register("test", func() TreeNodeModel { return &codecTestModel{} })
+	return registry
+}
+
+func TestModelRegistry_RoundTripTree(t *testing.T) {
+	registry := codecRegistry()
+	container := NewTreeContainer()
+	branch := NewTreeNode(NewStaticModel(theme.FolderIcon(), "Branch"))
+	leaf := NewLeafTreeNode(&codecTestModel{Name: "Leaf", Count: 3})
+	condensed := NewTreeNode(NewStaticModel(nil, "Condensed"))
+	_ = branch.Append(leaf)
+	_ = branch.Append(condensed)
+	_ = condensed.Append(NewLeafTreeNode(NewStaticModel(nil, "Hidden")))
+	branch.Expand()
+	_ = container.Append(branch)
+	_ = container.Append(NewLeafTreeNode(NewStaticModel(nil, "Second")))
+
+	data, err := registry.MarshalTree(container)
+	if err != nil {
+		t.Fatalf("Failed to marshal tree: %v", err)
+	}
+
+	loaded := NewTreeContainer()
+	if err := registry.UnmarshalTree(data, loaded); err != nil {
+		t.Fatalf("Failed to unmarshal tree: %v", err)
+	}
+	if loaded.NumRoots() != 2 {
+		t.Fatalf("Expected 2 roots, got %d", loaded.NumRoots())
+	}
+	loadedBranch := loaded.Objects[0].(*TreeNode)
+	if loadedBranch.GetModelText() != "Branch" || !loadedBranch.IsExpanded() || loadedBranch.NumChildren() != 2 {
+		t.Fatalf("Branch was not restored correctly")
+	}
+	if icon := loadedBranch.GetModelIconResource(); icon == nil || icon.Name() != theme.FolderIcon().Name() {
+		t.Fatalf("Branch icon was not restored")
+	}
+	loadedLeaf := loadedBranch.Objects[0].(*TreeNode)
+	model, ok := loadedLeaf.model.(*codecTestModel)
+	if !ok || model.Count != 3 || model.node != loadedLeaf || !loadedLeaf.IsLeaf() {
+		t.Fatalf("Custom model leaf was not restored correctly")
+	}
+	loadedCondensed := loadedBranch.Objects[1].(*TreeNode)
+	if loadedCondensed.IsExpanded() || loadedCondensed.NumChildren() != 1 {
+		t.Fatalf("Condensed branch was not restored correctly")
+	}
+}
+
+func TestModelRegistry_UnregisteredModel(t *testing.T) {
+	registry := NewModelRegistry()
+	if _, err := registry.MarshalNode(NewTreeNode(&codecTestModel{})); err == nil {
+		t.Fatalf("Expected error marshalling unregistered model")
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"type": "missing", "model": map[string]string{}})
+	if _, err := registry.UnmarshalNode(data); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("Expected error naming the unregistered type, got %v", err)
+	}
+}
+
+func TestModelRegistry_SkipsLazyChildren(t *testing.T) {
+	registry := codecRegistry()
+	registry.Register("lazy", func() TreeNodeModel { return &lazyModel{} })
+	node := NewTreeNode(&lazyModel{StaticNodeModel: StaticNodeModel{Text: "Lazy"}, children: []string{"Child"}})
+	_ = node.Append(NewLeafTreeNode(NewStaticModel(nil, "Loaded")))
+
+	data, err := registry.MarshalNode(node)
+	if err != nil {
+		t.Fatalf("Failed to marshal node: %v", err)
+	}
+	if strings.Contains(string(data), "Loaded") {
+		t.Fatalf("Expected lazily loaded children to be skipped: %s", data)
+	}
+}
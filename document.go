@@ -0,0 +1,363 @@
+package fynetree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+	"github.com/drognisep/fynetree/util"
+	"gopkg.in/yaml.v2"
+)
+
+var _ TreeNodeModel = (*DocumentModel)(nil)
+var _ KeyedModel = (*DocumentModel)(nil)
+
+// DocumentPageSize is the most elements an array node lists directly. Larger arrays are split into ranges of this
+// many elements, each listed when it's first expanded.
+const DocumentPageSize = 100
+
+// DocumentKind is the type of a value in a decoded document.
+type DocumentKind int
+
+const (
+	// DocumentNull is a null value.
+	DocumentNull DocumentKind = iota
+	// DocumentBool is true or false.
+	DocumentBool
+	// DocumentNumber is an integer or floating point number.
+	DocumentNumber
+	// DocumentString is a string, or any other scalar.
+	DocumentString
+	// DocumentObject is a set of named members.
+	DocumentObject
+	// DocumentArray is a list of elements.
+	DocumentArray
+	// DocumentRange is a range of elements of a large array, and isn't part of the document.
+	DocumentRange
+)
+
+// DocumentModel shows a value from a decoded JSON or YAML document. Scalars are shown as "key: value" leaves, and
+// objects and arrays list their members when first expanded.
+type DocumentModel struct {
+	// Value is the decoded value.
+	Value interface{}
+
+	parent     *DocumentModel
+	token      string
+	name       string
+	kind       DocumentKind
+	start, end int
+
+	mux    sync.Mutex
+	loaded bool
+	node   *TreeNode
+}
+
+// documentMember is a member of an object, kept in document order.
+type documentMember struct {
+	key   string
+	value interface{}
+}
+
+// NewDocumentNode creates a node for a decoded document, such as one unmarshalled by encoding/json into an
+// interface{}. The root is labelled with the name. Members of Go maps are shown sorted by key.
+func NewDocumentNode(name string, value interface{}) *TreeNode {
+	return NewTreeNode(newDocumentModel(nil, "", name, value))
+}
+
+// ParseJSON decodes a JSON document into a node. Objects are decoded as a yaml.MapSlice so their members are kept in
+// document order, and numbers as a json.Number.
+func ParseJSON(name string, data []byte) (*TreeNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON document")
+	}
+	return NewDocumentNode(name, value), nil
+}
+
+// ParseYAML decodes a YAML document into a node. Members of mappings are kept in document order.
+func ParseYAML(name string, data []byte) (*TreeNode, error) {
+	var mapping yaml.MapSlice
+	if err := yaml.Unmarshal(data, &mapping); err == nil {
+		return NewDocumentNode(name, mapping), nil
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return NewDocumentNode(name, value), nil
+}
+
+// decodeJSONValue reads the next value from the decoder, with objects as ordered members.
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		members := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = decoder.Token()
+		return members, err
+	case json.Delim('['):
+		elements := []interface{}{}
+		for decoder.More() {
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, value)
+		}
+		_, err = decoder.Token()
+		return elements, err
+	}
+	return token, nil
+}
+
+func newDocumentModel(parent *DocumentModel, token, name string, value interface{}) *DocumentModel {
+	m := &DocumentModel{Value: value, parent: parent, token: token, name: name}
+	switch v := value.(type) {
+	case nil:
+		m.kind = DocumentNull
+	case bool:
+		m.kind = DocumentBool
+	case json.Number, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		m.kind = DocumentNumber
+	case []interface{}:
+		m.kind = DocumentArray
+		m.end = len(v)
+	case yaml.MapSlice, map[string]interface{}, map[interface{}]interface{}:
+		m.kind = DocumentObject
+	default:
+		m.kind = DocumentString
+	}
+	return m
+}
+
+// Kind returns the type of the value.
+func (m *DocumentModel) Kind() DocumentKind {
+	return m.kind
+}
+
+// Pointer returns the JSON pointer of the value within its document, as described in RFC 6901. The root's pointer is
+// empty.
+func (m *DocumentModel) Pointer() string {
+	if m.kind == DocumentRange {
+		return m.parent.Pointer()
+	}
+	if m.parent == nil {
+		return ""
+	}
+	token := strings.NewReplacer("~", "~0", "/", "~1").Replace(m.token)
+	return m.parent.Pointer() + "/" + token
+}
+
+// JSONPointer returns the JSON pointer of a node created from a document. False is returned if the node wasn't.
+func JSONPointer(node *TreeNode) (string, bool) {
+	if node == nil {
+		return "", false
+	}
+	model, ok := node.model.(*DocumentModel)
+	if !ok {
+		return "", false
+	}
+	return model.Pointer(), true
+}
+
+// GetIconResource picks an icon from the theme for the type of the value.
+func (m *DocumentModel) GetIconResource() fyne.Resource {
+	node := m.treeNode()
+	return kindIcon(m.kind, m.Value == true, node != nil && node.IsExpanded())
+}
+
+// kindIcon picks an icon from the theme for a type of value. Booleans show whether they're set, and objects whether
+// they're expanded.
+func kindIcon(kind DocumentKind, set, expanded bool) fyne.Resource {
+	switch kind {
+	case DocumentNull:
+		return theme.ContentClearIcon()
+	case DocumentBool:
+		if set {
+			return theme.CheckButtonCheckedIcon()
+		}
+		return theme.CheckButtonIcon()
+	case DocumentNumber:
+		return theme.InfoIcon()
+	case DocumentObject:
+		if expanded {
+			return theme.FolderOpenIcon()
+		}
+		return theme.FolderIcon()
+	case DocumentArray, DocumentRange:
+		return theme.MenuIcon()
+	}
+	return theme.FileTextIcon()
+}
+
+// GetText returns "key: value" for scalars, and the key with the number of members for objects and arrays. Array
+// elements are keyed by their index.
+func (m *DocumentModel) GetText() string {
+	label := m.name
+	if m.parent != nil && m.parent.kind == DocumentArray {
+		label = "[" + m.token + "]"
+	}
+	var value string
+	switch m.kind {
+	case DocumentObject:
+		value = fmt.Sprintf("{%d}", len(documentMembers(m.Value)))
+	case DocumentArray:
+		value = fmt.Sprintf("[%d]", m.end)
+	case DocumentRange:
+		return fmt.Sprintf("[%d…%d]", m.start, m.end-1)
+	default:
+		value = scalarText(m.Value)
+		if label != "" {
+			label += ":"
+		}
+	}
+	if label == "" {
+		return value
+	}
+	return label + " " + value
+}
+
+// GetKey returns the JSON pointer, so expansion state can be restored after the document is reloaded.
+func (m *DocumentModel) GetKey() string {
+	if m.kind == DocumentRange {
+		return fmt.Sprintf("%s/[%d-%d]", m.Pointer(), m.start, m.end)
+	}
+	return m.Pointer()
+}
+
+// SetTreeNode shows scalars and empty values as leaves, and lists members before the node is first expanded.
+func (m *DocumentModel) SetTreeNode(node *TreeNode) {
+	m.mux.Lock()
+	m.node = node
+	m.mux.Unlock()
+	if m.empty() {
+		node.SetLeaf()
+		return
+	}
+	node.OnBeforeExpand = func() {
+		m.mux.Lock()
+		loaded := m.loaded
+		m.loaded = true
+		m.mux.Unlock()
+		if !loaded {
+			for _, child := range m.children() {
+				if err := node.Append(NewTreeNode(child)); err != nil {
+					fyne.LogError("Unable to add document member", err)
+				}
+			}
+		}
+	}
+}
+
+// empty returns whether the value has no members. Scalars are always empty.
+func (m *DocumentModel) empty() bool {
+	switch m.kind {
+	case DocumentObject:
+		return len(documentMembers(m.Value)) == 0
+	case DocumentArray, DocumentRange:
+		return m.end == m.start
+	}
+	return true
+}
+
+func (m *DocumentModel) ownsChildren() bool {
+	return true
+}
+
+func (m *DocumentModel) treeNode() *TreeNode {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.node
+}
+
+// children creates models for the members of an object, or the elements of an array or range. Arrays with more than
+// DocumentPageSize elements are split into ranges.
+func (m *DocumentModel) children() []*DocumentModel {
+	var children []*DocumentModel
+	switch m.kind {
+	case DocumentObject:
+		for _, member := range documentMembers(m.Value) {
+			children = append(children, newDocumentModel(m, member.key, member.key, member.value))
+		}
+	case DocumentArray, DocumentRange:
+		array := m
+		if m.kind == DocumentRange {
+			array = m.parent
+		}
+		elements := array.Value.([]interface{})
+		if m.end-m.start > DocumentPageSize {
+			for start := m.start; start < m.end; start += DocumentPageSize {
+				end := util.IntMin(start+DocumentPageSize, m.end)
+				children = append(children, &DocumentModel{parent: array, kind: DocumentRange, start: start, end: end})
+			}
+			return children
+		}
+		for i := m.start; i < m.end; i++ {
+			children = append(children, newDocumentModel(array, strconv.Itoa(i), "", elements[i]))
+		}
+	}
+	return children
+}
+
+// documentMembers lists the members of an object, in document order or sorted by key for Go maps.
+func documentMembers(value interface{}) []documentMember {
+	var members []documentMember
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range v {
+			members = append(members, documentMember{key: fmt.Sprint(item.Key), value: item.Value})
+		}
+		return members
+	case map[string]interface{}:
+		for key, value := range v {
+			members = append(members, documentMember{key: key, value: value})
+		}
+	case map[interface{}]interface{}:
+		for key, value := range v {
+			members = append(members, documentMember{key: fmt.Sprint(key), value: value})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].key < members[j].key
+	})
+	return members
+}
+
+// scalarText formats a scalar the way it would be written in JSON.
+func scalarText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,129 @@
+package fynetree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/theme"
+)
+
+const testJSON = `{"name": "tree", "version": 2, "tags": ["a", "b"], "meta": {"a/b": true, "~x": null}, "empty": {}}`
+
+func TestParseJSON(t *testing.T) {
+	if _, err := ParseJSON("bad", []byte(`{"a": `)); err == nil {
+		t.Fatalf("Expected error for invalid JSON")
+	}
+	if _, err := ParseJSON("bad", []byte(`{} {}`)); err == nil {
+		t.Fatalf("Expected error for trailing data")
+	}
+	root, err := ParseJSON("doc.json", []byte(testJSON))
+	if err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if root.GetModelText() != "doc.json {5}" || root.NumChildren() != 0 {
+		t.Fatalf("Expected an unlisted object labelled with its size, got '%s'", root.GetModelText())
+	}
+	root.Expand()
+	if got := joinedChildTexts(root); got != `name: "tree",version: 2,tags [2],meta {2},empty {0}` {
+		t.Fatalf("Expected members in document order, got %s", got)
+	}
+	if !root.Children()[4].IsLeaf() {
+		t.Fatalf("Expected an empty object to be a leaf")
+	}
+
+	tags := root.Children()[2]
+	tags.Expand()
+	if got := joinedChildTexts(tags); got != `[0]: "a",[1]: "b"` {
+		t.Fatalf("Expected indexed elements, got %s", got)
+	}
+	meta := root.Children()[3]
+	meta.Expand()
+	flag, null := meta.Children()[0], meta.Children()[1]
+	if flag.GetModelIconResource() != theme.CheckButtonCheckedIcon() || null.GetModelText() != "~x: null" {
+		t.Fatalf("Expected type specific icons and labels")
+	}
+
+	pointers := map[*TreeNode]string{root: "", tags.Children()[1]: "/tags/1", flag: "/meta/a~1b", null: "/meta/~0x"}
+	for node, want := range pointers {
+		if got, ok := JSONPointer(node); !ok || got != want {
+			t.Fatalf("Expected pointer '%s', got '%s'", want, got)
+		}
+	}
+	if _, ok := JSONPointer(NewTreeNode(&StaticNodeModel{Text: "static"})); ok {
+		t.Fatalf("Expected no pointer for other models")
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	root, err := ParseYAML("doc.yaml", []byte("zeta: 1\nalpha:\n  - x: true\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	root.Expand()
+	if got := joinedChildTexts(root); got != "zeta: 1,alpha [1]" {
+		t.Fatalf("Expected mapping in document order, got %s", got)
+	}
+	alpha := root.Children()[1]
+	alpha.Expand()
+	element := alpha.Children()[0]
+	element.Expand()
+	if got := joinedChildTexts(element); got != "x: true" {
+		t.Fatalf("Expected nested mapping, got %s", got)
+	}
+	if pointer, _ := JSONPointer(element.Children()[0]); pointer != "/alpha/0/x" {
+		t.Fatalf("Expected nested pointer, got '%s'", pointer)
+	}
+
+	list, err := ParseYAML("list.yaml", []byte("- 1\n- two\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse YAML sequence: %v", err)
+	}
+	list.Expand()
+	if got := joinedChildTexts(list); got != `[0]: 1,[1]: "two"` {
+		t.Fatalf("Expected sequence elements, got %s", got)
+	}
+}
+
+func TestDocumentModel_LargeArray(t *testing.T) {
+	elements := make([]string, 2*DocumentPageSize+5)
+	for i := range elements {
+		elements[i] = fmt.Sprint(i)
+	}
+	root, err := ParseJSON("", []byte("["+strings.Join(elements, ",")+"]"))
+	if err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if root.GetModelText() != fmt.Sprintf("[%d]", len(elements)) {
+		t.Fatalf("Expected unnamed root to show its size, got '%s'", root.GetModelText())
+	}
+	root.Expand()
+	if got := joinedChildTexts(root); got != "[0…99],[100…199],[200…204]" {
+		t.Fatalf("Expected ranges of elements, got %s", got)
+	}
+	last := root.Children()[2]
+	if last.NumChildren() != 0 {
+		t.Fatalf("Expected ranges to be listed lazily")
+	}
+	last.Expand()
+	if got := joinedChildTexts(last); got != "[200]: 200,[201]: 201,[202]: 202,[203]: 203,[204]: 204" {
+		t.Fatalf("Expected range elements, got %s", got)
+	}
+	if pointer, _ := JSONPointer(last.Children()[0]); pointer != "/200" {
+		t.Fatalf("Expected ranges to be left out of pointers, got '%s'", pointer)
+	}
+	if last.Key() == root.Children()[1].Key() {
+		t.Fatalf("Expected ranges to have distinct keys")
+	}
+}
+
+func TestNewDocumentNode_Maps(t *testing.T) {
+	root := NewDocumentNode("map", map[string]interface{}{"b": 2.5, "a": []interface{}{}})
+	root.Expand()
+	if got := joinedChildTexts(root); got != "a [0],b: 2.5" {
+		t.Fatalf("Expected map members sorted by key, got %s", got)
+	}
+	if !root.Children()[0].IsLeaf() {
+		t.Fatalf("Expected an empty array to be a leaf")
+	}
+}
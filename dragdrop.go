@@ -0,0 +1,196 @@
+package fynetree
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne"
+)
+
+// DropPosition describes where a dragged node will be placed relative to the drop target.
+type DropPosition int
+
+const (
+	// DropBefore places the dragged node as the previous sibling of the target.
+	DropBefore DropPosition = iota
+	// DropAfter places the dragged node as the next sibling of the target.
+	DropAfter
+	// DropInto places the dragged node as the last child of the target.
+	DropInto
+)
+
+// DropVetoHandler is called before a drop is performed, and may return false to prevent it.
+type DropVetoHandler func(dragged, target *TreeNode, position DropPosition) bool
+
+// DropHandler is called after a drop has been performed.
+type DropHandler func(dragged, target *TreeNode, position DropPosition)
+
+// Dragged is called while the node is being dragged, and tracks the drop target under the pointer.
+func (n *TreeNode) Dragged(ev *fyne.DragEvent) {
+	if c := n.treeContainer(); c != nil && c.DragAndDrop {
+		c.nodeDragged(n, ev)
+	}
+}
+
+// DragEnd is called when the node is dropped.
+func (n *TreeNode) DragEnd() {
+	if c := n.treeContainer(); c != nil && c.DragAndDrop {
+		c.nodeDragEnd(n)
+	}
+}
+
+// MoveNode removes the node from its current position and places it relative to the target. The node and its
+// descendants stay selected if they were. A node moved into an auto-sorted list is placed in sorted order instead.
+// An error is returned if the node would be moved into itself, one of its descendants, a leaf or a placeholder, in
+// which case it's left where it was.
+func (t *TreeContainer) MoveNode(node, target *TreeNode, position DropPosition) error {
+	if node == nil || target == nil {
+		return errors.New("unable to move nil node")
+	}
+	if node == target || target.isDescendantOf(node) {
+		return errors.New("unable to move node into itself")
+	}
+	if node.treeContainer() != t || target.treeContainer() != t {
+		return errors.New("nodes must both be in this container")
+	}
+	if target.IsPlaceholder() || (position == DropInto && target.IsLeaf()) {
+		return errors.New("unable to move node into a leaf")
+	}
+	if position != DropBefore && position != DropAfter && position != DropInto {
+		return fmt.Errorf("unknown drop position %d", position)
+	}
+
+	if t.History != nil {
+		t.History.BeginTransaction()
+		defer t.History.EndTransaction()
+	}
+	t.mux.Lock()
+	selected := t.copySelected()
+	anchor := t.anchor
+	t.mux.Unlock()
+	from := t.siblingList(node)
+	index := from.IndexOf(node)
+	if _, err := from.Remove(node); err != nil {
+		return err
+	}
+	var err error
+	switch position {
+	case DropInto:
+		err = target.Append(node)
+		target.Expand()
+	case DropAfter:
+		list := t.siblingList(target)
+		err = list.insertNear(list.IndexOf(target)+1, node)
+	default:
+		list := t.siblingList(target)
+		err = list.insertNear(list.IndexOf(target), node)
+	}
+	if err != nil {
+		if restoreErr := from.insertAt(index, node); restoreErr != nil {
+			fyne.LogError("Unable to restore node after a failed move", restoreErr)
+		}
+	}
+	t.restoreSelection(node, selected, anchor)
+	return err
+}
+
+// restoreSelection selects the moved node and its descendants again if they were selected before it was moved.
+func (t *TreeContainer) restoreSelection(moved *TreeNode, selected []*TreeNode, anchor *TreeNode) {
+	for _, s := range selected {
+		if s == moved || s.isDescendantOf(moved) {
+			t.setSelection(selected, anchor)
+			return
+		}
+	}
+}
+
+// pruneDrag stops tracking a drag of a removed node, or a drop onto one.
+func (t *TreeContainer) pruneDrag(removed *TreeNode) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.dragging == removed || (t.dragging != nil && t.dragging.isDescendantOf(removed)) {
+		t.dragging = nil
+		t.dropTarget = nil
+	}
+	if t.dropTarget == removed || (t.dropTarget != nil && t.dropTarget.isDescendantOf(removed)) {
+		t.dropTarget = nil
+	}
+}
+
+// siblingList gets the list that holds the given node, either its parent's children or this container's roots.
+func (t *TreeContainer) siblingList(node *TreeNode) *nodeList {
+	if parent := node.GetParent(); parent != nil {
+		return parent.nodeList
+	}
+	return t.nodeList
+}
+
+func (t *TreeContainer) nodeDragged(node *TreeNode, ev *fyne.DragEvent) {
+	pos := ev.AbsolutePosition
+	if app := fyne.CurrentApp(); app != nil {
+		pos = pos.Subtract(app.Driver().AbsolutePositionForObject(t.viewport))
+	}
+	target, position := t.dropTargetAt(pos)
+	if target == node || (target != nil && target.isDescendantOf(node)) {
+		target = nil
+	}
+
+	t.mux.Lock()
+	changed := t.dragging != node || t.dropTarget != target || t.dropPosition != position
+	t.dragging = node
+	t.dropTarget = target
+	t.dropPosition = position
+	t.mux.Unlock()
+	if changed {
+		t.BaseWidget.Refresh()
+	}
+}
+
+func (t *TreeContainer) nodeDragEnd(node *TreeNode) {
+	t.mux.Lock()
+	target := t.dropTarget
+	position := t.dropPosition
+	dragging := t.dragging
+	t.dragging = nil
+	t.dropTarget = nil
+	t.mux.Unlock()
+	t.BaseWidget.Refresh()
+
+	if dragging != node || target == nil {
+		return
+	}
+	if t.OnBeforeDrop != nil && !t.OnBeforeDrop(node, target, position) {
+		return
+	}
+	if err := t.MoveNode(node, target, position); err != nil {
+		fyne.LogError("Unable to drop node", err)
+		return
+	}
+	if t.OnAfterDrop != nil {
+		t.OnAfterDrop(node, target, position)
+	}
+}
+
+// dropTargetAt finds the visible node at the position relative to the scrolled rows, and where a drop would place a node.
+// Branches accept drops into the middle half of their row, while leaves only accept drops before or after.
+func (t *TreeContainer) dropTargetAt(pos fyne.Position) (*TreeNode, DropPosition) {
+	visible := t.visibleNodes()
+	rowHeight := t.viewport.rowHeight()
+	if pos.Y < 0 || pos.Y >= len(visible)*rowHeight {
+		return nil, DropBefore
+	}
+	node := visible[pos.Y/rowHeight]
+	relY := pos.Y % rowHeight
+	if node.IsBranch() {
+		if relY < rowHeight/4 {
+			return node, DropBefore
+		} else if relY >= rowHeight*3/4 {
+			return node, DropAfter
+		}
+		return node, DropInto
+	}
+	if relY < rowHeight/2 {
+		return node, DropBefore
+	}
+	return node, DropAfter
+}
@@ -0,0 +1,129 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+)
+
+func assertChildren(t *testing.T, list *nodeList, want ...*TreeNode) {
+	t.Helper()
+	if got := list.Len(); got != len(want) {
+		t.Fatalf("Expected %d nodes, got %d", len(want), got)
+	}
+	for i, n := range want {
+		if list.Objects[i] != n {
+			t.Fatalf("Expected node '%s' at position %d", n.GetModelText(), i)
+		}
+	}
+}
+
+func TestTreeContainer_MoveNode(t *testing.T) {
+	selectionSetup()
+
+	if err := treeContainer.MoveNode(nodeC, nodeA, DropBefore); err != nil {
+		t.Fatalf("Failed to move node C before A: %v", err)
+	}
+	assertChildren(t, treeContainer.nodeList, nodeC, nodeA, nodeB)
+
+	if err := treeContainer.MoveNode(nodeC, nodeD, DropAfter); err != nil {
+		t.Fatalf("Failed to move node C after D: %v", err)
+	}
+	assertChildren(t, treeContainer.nodeList, nodeA, nodeB)
+	assertChildren(t, nodeB.nodeList, nodeD, nodeC)
+	if nodeC.GetParent() != nodeB {
+		t.Fatalf("Node C should now be a child of node B")
+	}
+
+	if err := treeContainer.MoveNode(nodeA, nodeD, DropInto); err != nil {
+		t.Fatalf("Failed to move node A into D: %v", err)
+	}
+	assertChildren(t, nodeD.nodeList, nodeA)
+	if !nodeD.IsExpanded() {
+		t.Fatalf("Drop target should be expanded after dropping into it")
+	}
+
+	if err := treeContainer.MoveNode(nodeB, nodeA, DropInto); err == nil {
+		t.Fatalf("Moving a node into its own descendant should fail")
+	}
+	if err := treeContainer.MoveNode(nodeB, nodeB, DropAfter); err == nil {
+		t.Fatalf("Moving a node relative to itself should fail")
+	}
+}
+
+func TestTreeContainer_MoveNodeKeepsState(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionMulti
+	treeContainer.Select(nodeB)
+	treeContainer.Select(nodeD)
+
+	if err := treeContainer.MoveNode(nodeB, nodeA, DropBefore); err != nil {
+		t.Fatalf("Failed to move node B before A: %v", err)
+	}
+	assertSelection(t, nodeB, nodeD)
+
+	nodeC.SetLeaf()
+	if err := treeContainer.MoveNode(nodeA, nodeC, DropInto); err == nil {
+		t.Fatalf("Moving a node into a leaf should fail")
+	}
+	assertChildren(t, treeContainer.nodeList, nodeB, nodeA, nodeC)
+}
+
+func TestTreeContainer_DragAndDrop(t *testing.T) {
+	selectionSetup()
+	treeContainer.DragAndDrop = true
+	testApp := test.NewApp()
+	win := testApp.NewWindow("Drag")
+	win.SetContent(treeContainer)
+	win.Resize(fyne.NewSize(200, 300))
+	defer win.Close()
+
+	dragTo := func(dragged, target *TreeNode, offsetY int) {
+		rowPos, _ := treeContainer.viewport.rowBounds(target)
+		abs := testApp.Driver().AbsolutePositionForObject(treeContainer.viewport).Add(rowPos).Add(fyne.NewPos(5, offsetY))
+		dragged.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{AbsolutePosition: abs}})
+		dragged.DragEnd()
+	}
+
+	var vetoed, dropped int
+	treeContainer.OnBeforeDrop = func(dragged, target *TreeNode, position DropPosition) bool {
+		if target == nodeD {
+			vetoed++
+			return false
+		}
+		return true
+	}
+	treeContainer.OnAfterDrop = func(dragged, target *TreeNode, position DropPosition) {
+		dropped++
+	}
+
+	dragTo(nodeC, nodeA, 1)
+	assertChildren(t, treeContainer.nodeList, nodeC, nodeA, nodeB)
+
+	_, rowSize := treeContainer.viewport.rowBounds(nodeB)
+	dragTo(nodeC, nodeD, rowSize.Height-1)
+	if vetoed != 1 {
+		t.Fatalf("Expected drop onto node D to be vetoed")
+	}
+	assertChildren(t, treeContainer.nodeList, nodeC, nodeA, nodeB)
+
+	dragTo(nodeA, nodeB, rowSize.Height/2)
+	assertChildren(t, nodeB.nodeList, nodeD, nodeA)
+
+	dragTo(nodeB, nodeD, 1)
+	assertChildren(t, treeContainer.nodeList, nodeC, nodeB)
+
+	if dropped != 2 {
+		t.Fatalf("Expected 2 completed drops, got %d", dropped)
+	}
+
+	rowPos, _ := treeContainer.viewport.rowBounds(nodeB)
+	abs := testApp.Driver().AbsolutePositionForObject(treeContainer.viewport).Add(rowPos).Add(fyne.NewPos(5, 1))
+	nodeC.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{AbsolutePosition: abs}})
+	_, _ = treeContainer.Remove(nodeB)
+	if treeContainer.dropTarget != nil {
+		t.Fatalf("Expected a drop target removed during the drag to be cleared")
+	}
+	nodeC.DragEnd()
+}
@@ -1,18 +1,29 @@
 package example
 
 import (
+	"errors"
+
 	"fyne.io/fyne"
 	"fyne.io/fyne/theme"
 	"github.com/drognisep/fynetree"
 )
 
 var _ fynetree.TreeNodeModel = (*Task)(nil)
+var _ fynetree.EditableModel = (*Task)(nil)
+var _ fynetree.TooltipModel = (*Task)(nil)
+var _ fynetree.MenuModel = (*Task)(nil)
 
 type Task struct {
-	Summary     string
-	Description string
-	Node        *fynetree.TreeNode
-	Menu        *fyne.Menu
+	fynetree.ModelNotifier
+	Summary     string             `json:"summary"`
+	Description string             `json:"description"`
+	Node        *fynetree.TreeNode `json:"-"`
+	Menu        *fyne.Menu         `json:"-"`
+}
+
+func init() {
+	// Allows trees containing tasks to be saved and loaded
+	fynetree.RegisterModel("example.Task", func() fynetree.TreeNodeModel { return &Task{} })
 }
 
 func (t *Task) SetTreeNode(node *fynetree.TreeNode) {
@@ -35,3 +46,28 @@ func (t *Task) GetIconResource() fyne.Resource {
 func (t *Task) GetText() string {
 	return t.Summary
 }
+
+// SetSummary changes the task's summary, and updates its node's label.
+func (t *Task) SetSummary(summary string) {
+	t.Summary = summary
+	t.NotifyChanged()
+}
+
+// SetText allows tasks to be renamed in place, as long as the summary isn't empty.
+func (t *Task) SetText(text string) error {
+	if text == "" {
+		return errors.New("task summary must not be empty")
+	}
+	t.SetSummary(text)
+	return nil
+}
+
+// GetTooltip shows the task's description when it's hovered.
+func (t *Task) GetTooltip() string {
+	return t.Description
+}
+
+// GetContextMenu shows the task's menu when it's tapped with the secondary button.
+func (t *Task) GetContextMenu(_ []*fynetree.TreeNode) *fyne.Menu {
+	return t.Menu
+}
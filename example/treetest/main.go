@@ -19,6 +19,16 @@ func main() {
 
 	// Create a ready-made container
 	treeContainer := fynetree.NewTreeContainer()
+	// Allow nodes to be reorganized by dragging them
+	treeContainer.DragAndDrop = true
+	// Allow tasks to be renamed with F2, double-click or by clicking a selected node
+	treeContainer.InlineRename = true
+	// Record changes so they can be undone with ctrl+Z and redone with ctrl+Y
+	treeContainer.History = fynetree.NewHistory()
+	// Draw lines joining each task to its parent
+	treeContainer.Style = fynetree.TreeStyle{GuideLines: true}
+	// Add the built-in actions to the context menu of every node, after any items from the node's model
+	treeContainer.MenuActions = fynetree.MenuAllActions
 	// Used to make a node and model at the same time
 	rootModel := fynetree.NewStaticBoundModel(theme.FolderOpenIcon(), "Tasks")
 	// Or created separately with a provided model
@@ -36,11 +46,6 @@ func main() {
 	}
 	// Factory methods for creating leaf/branch nodes, can be easily changed later
 	exampleNode := fynetree.NewLeafTreeNode(exampleTask)
-	// General event handler
-	exampleNode.OnTappedSecondary = func(pe *fyne.PointEvent) {
-		canvas := fyne.CurrentApp().Driver().CanvasForObject(exampleNode)
-		widget.ShowPopUpMenuAtPosition(exampleTask.Menu, canvas, pe.AbsolutePosition)
-	}
 	exampleNode.OnDoubleTapped = func(pe *fyne.PointEvent) { createPopupFunc("Hello from node double-tapped")() }
 	// Icon tap event handler
 	exampleNode.OnIconTapped = func(pe *fyne.PointEvent) { createPopupFunc("Hello from icon tapped!")() }
@@ -51,9 +56,19 @@ func main() {
 	_ = treeContainer.Append(notesNode)
 
 	addBtn := widget.NewButton("Add Task", addBtnClicked(rootModel.Node, win))
-	btnBox := container.NewVBox(addBtn)
+	// Only show matching nodes and their ancestors while searching
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder("Search")
+	searchEntry.OnChanged = func(query string) {
+		if query == "" {
+			treeContainer.ClearFilter()
+		} else {
+			treeContainer.SetFilter(fynetree.FuzzyFilter(query))
+		}
+	}
+	btnBox := container.NewVBox(searchEntry, addBtn)
 
-	split := container.NewHSplit(container.NewScroll(treeContainer), fyne.NewContainerWithLayout(
+	split := container.NewHSplit(treeContainer, fyne.NewContainerWithLayout(
 		layout.NewBorderLayout(nil, btnBox, nil, nil),
 		btnBox,
 		example.NewDetailView(exampleTask),
@@ -2,7 +2,6 @@ package fynetree
 
 import (
 	"fyne.io/fyne"
-	"fyne.io/fyne/theme"
 	"fyne.io/fyne/widget"
 )
 
@@ -27,10 +26,11 @@ func (e *expandHandle) Refresh() {
 			e.Hide()
 		} else {
 			e.Show()
+			style := nodeStyle(e.node)
 			if e.node.IsExpanded() {
-				e.SetResource(theme.MenuDropDownIcon())
+				e.SetResource(style.CondenseIcon)
 			} else {
-				e.SetResource(theme.MenuExpandIcon())
+				e.SetResource(style.ExpandIcon)
 			}
 		}
 	}
@@ -0,0 +1,175 @@
+package fynetree
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne"
+)
+
+// ExpansionState is the set of keys of expanded nodes, as returned by TreeNode.Key.
+type ExpansionState map[string]bool
+
+// Key returns a key identifying this node. The key from a KeyedModel is used if there is one, otherwise the key is the
+// path of node texts from the root, separated by "/".
+func (n *TreeNode) Key() string {
+	if keyed, ok := n.model.(KeyedModel); ok {
+		return keyed.GetKey()
+	}
+	var texts []string
+	for node := n; node != nil; node = node.parent {
+		texts = append([]string{escapeKeyText(node.GetModelText())}, texts...)
+	}
+	return strings.Join(texts, "/")
+}
+
+func escapeKeyText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "/", `\/`).Replace(text)
+}
+
+// ExpansionState captures the keys of every expanded node in the container.
+func (t *TreeContainer) ExpansionState() ExpansionState {
+	state := ExpansionState{}
+	_ = t.Walk(PreOrder, func(node *TreeNode) error {
+		if node.IsBranch() && node.IsExpanded() && !node.IsPlaceholder() {
+			state[node.Key()] = true
+		}
+		return nil
+	})
+	return state
+}
+
+// RestoreExpansion expands every node whose key is in the state. The state is remembered and kept up to date as nodes
+// are expanded and condensed, so nodes added later, such as lazily loaded children or a rebuilt tree, are expanded to
+// match. Restoring isn't recorded in the container's history.
+func (t *TreeContainer) RestoreExpansion(state ExpansionState) {
+	remembered := ExpansionState{}
+	for key, expanded := range state {
+		if expanded {
+			remembered[key] = true
+		}
+	}
+	t.mux.Lock()
+	t.expansion = remembered
+	t.mux.Unlock()
+	t.Batch(func() {
+		for _, root := range t.Children() {
+			t.restoreExpansion(root)
+		}
+	})
+}
+
+// PersistExpansion restores the expansion state saved in the preferences under the given key, then saves the state
+// there whenever a node is expanded or condensed.
+func (t *TreeContainer) PersistExpansion(prefs fyne.Preferences, key string) {
+	state := LoadExpansionState(prefs, key)
+	t.mux.Lock()
+	t.expansionPrefs = prefs
+	t.expansionPrefsKey = key
+	t.mux.Unlock()
+	t.RestoreExpansion(state)
+}
+
+// SaveExpansionState stores the state in the preferences under the given key.
+func SaveExpansionState(prefs fyne.Preferences, key string, state ExpansionState) {
+	keys := make([]string, 0, len(state))
+	for nodeKey, expanded := range state {
+		if expanded {
+			keys = append(keys, nodeKey)
+		}
+	}
+	sort.Strings(keys)
+	data, err := json.Marshal(keys)
+	if err != nil {
+		fyne.LogError("Unable to save expansion state", err)
+		return
+	}
+	prefs.SetString(key, string(data))
+}
+
+// LoadExpansionState reads the state stored in the preferences under the given key. An empty state is returned if
+// nothing has been stored.
+func LoadExpansionState(prefs fyne.Preferences, key string) ExpansionState {
+	state := ExpansionState{}
+	data := prefs.String(key)
+	if data == "" {
+		return state
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(data), &keys); err != nil {
+		fyne.LogError("Unable to load expansion state", err)
+		return state
+	}
+	for _, nodeKey := range keys {
+		state[nodeKey] = true
+	}
+	return state
+}
+
+// restoreExpansion expands the node and its descendants that are in the remembered state.
+func (t *TreeContainer) restoreExpansion(node *TreeNode) {
+	t.mux.Lock()
+	remembered := t.expansion
+	t.mux.Unlock()
+	if len(remembered) == 0 {
+		return
+	}
+	t.setRestoring(true)
+	defer t.setRestoring(false)
+	t.Batch(func() {
+		_ = node.Walk(PreOrder, func(child *TreeNode) error {
+			if child.IsBranch() && child.IsCondensed() && !child.IsPlaceholder() && t.isRemembered(child.Key()) {
+				child.Expand()
+			}
+			return nil
+		})
+	})
+}
+
+func (t *TreeContainer) isRemembered(key string) bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.expansion[key]
+}
+
+func (t *TreeContainer) setRestoring(restoring bool) {
+	t.mux.Lock()
+	t.restoring = restoring
+	t.mux.Unlock()
+}
+
+func (t *TreeContainer) isRestoring() bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.restoring
+}
+
+// expansionChanged updates the remembered state after a node is expanded or condensed, and saves it if it's persisted.
+func (t *TreeContainer) expansionChanged(node *TreeNode) {
+	if node.IsPlaceholder() {
+		return
+	}
+	t.mux.Lock()
+	if t.expansion == nil {
+		t.mux.Unlock()
+		return
+	}
+	if node.IsExpanded() {
+		t.expansion[node.Key()] = true
+	} else {
+		delete(t.expansion, node.Key())
+	}
+	prefs, key := t.expansionPrefs, t.expansionPrefsKey
+	var state ExpansionState
+	if prefs != nil {
+		state = make(ExpansionState, len(t.expansion))
+		for nodeKey := range t.expansion {
+			state[nodeKey] = true
+		}
+	}
+	t.mux.Unlock()
+	if prefs != nil {
+		SaveExpansionState(prefs, key, state)
+	}
+}
@@ -0,0 +1,97 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne/test"
+)
+
+type keyedModel struct {
+	StaticNodeModel
+	key string
+}
+
+func (k *keyedModel) GetKey() string {
+	return k.key
+}
+
+// buildExpansionTree builds a fresh root -> (A -> (C, D), B) tree, like rebuilding it from new data would.
+func buildExpansionTree() map[string]*TreeNode {
+	nodes := map[string]*TreeNode{}
+	for _, text := range []string{"root", "A", "B", "C", "D"} {
+		nodes[text] = NewTreeNode(NewStaticModel(nil, text))
+	}
+	_ = nodes["root"].Append(nodes["A"])
+	_ = nodes["root"].Append(nodes["B"])
+	_ = nodes["A"].Append(nodes["C"])
+	_ = nodes["A"].Append(nodes["D"])
+	return nodes
+}
+
+func TestTreeNode_Key(t *testing.T) {
+	traversalSetup()
+	if key := nodeC.Key(); key != "root/A/C" {
+		t.Fatalf("Expected text path key, got '%s'", key)
+	}
+	slashed := NewTreeNode(NewStaticModel(nil, `a/b\c`))
+	_ = nodeC.Append(slashed)
+	if key := slashed.Key(); key != `root/A/C/a\/b\\c` {
+		t.Fatalf("Expected separators to be escaped, got '%s'", key)
+	}
+	keyed := NewTreeNode(&keyedModel{StaticNodeModel{Text: "keyed"}, "id-1"})
+	_ = nodeC.Append(keyed)
+	if key := keyed.Key(); key != "id-1" {
+		t.Fatalf("Expected model key, got '%s'", key)
+	}
+}
+
+func TestTreeContainer_RestoreExpansion(t *testing.T) {
+	traversalSetup()
+	rootNode.Expand()
+	nodeA.Expand()
+	state := treeContainer.ExpansionState()
+	if len(state) != 2 || !state["root"] || !state["root/A"] {
+		t.Fatalf("Expected root and A to be captured, got %v", state)
+	}
+
+	containerSetup()
+	nodes := buildExpansionTree()
+	_ = treeContainer.Append(nodes["root"])
+	treeContainer.History = NewHistory()
+	treeContainer.RestoreExpansion(state)
+	if !nodes["root"].IsExpanded() || !nodes["A"].IsExpanded() || nodes["B"].IsExpanded() {
+		t.Fatalf("Expected root and A to be expanded")
+	}
+	if treeContainer.History.CanUndo() {
+		t.Fatalf("Expected restoring not to be recorded in the history")
+	}
+
+	nodes["A"].Condense()
+	_, _ = treeContainer.Remove(nodes["root"])
+	rebuilt := buildExpansionTree()
+	_ = treeContainer.Append(rebuilt["root"])
+	if !rebuilt["root"].IsExpanded() || rebuilt["A"].IsExpanded() {
+		t.Fatalf("Expected a rebuilt tree to match the remembered state")
+	}
+}
+
+func TestTreeContainer_PersistExpansion(t *testing.T) {
+	prefs := test.NewApp().Preferences()
+	traversalSetup()
+	treeContainer.PersistExpansion(prefs, "tree")
+	nodeA.Expand()
+	if got := prefs.String("tree"); got != `["root/A"]` {
+		t.Fatalf("Expected expansion to be saved, got '%s'", got)
+	}
+
+	containerSetup()
+	nodes := buildExpansionTree()
+	_ = treeContainer.Append(nodes["root"])
+	treeContainer.PersistExpansion(prefs, "tree")
+	if !nodes["A"].IsExpanded() || nodes["root"].IsExpanded() {
+		t.Fatalf("Expected saved expansion to be restored")
+	}
+	if state := LoadExpansionState(prefs, "missing"); len(state) != 0 {
+		t.Fatalf("Expected an empty state for a missing key")
+	}
+}
@@ -0,0 +1,227 @@
+package fynetree
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+)
+
+var _ TreeNodeModel = (*FileSystemModel)(nil)
+var _ KeyedModel = (*FileSystemModel)(nil)
+
+// FileSystemModel shows a file or directory from an fs.FS. A directory lists its entries the first time its node is
+// expanded, with folders before files, and files are shown as leaves. Use WatchFileSystem to keep listings current.
+type FileSystemModel struct {
+	// FS is the filesystem holding the entry.
+	FS fs.FS
+	// Path is the entry's path within FS, as accepted by fs.ValidPath.
+	Path string
+
+	mux     sync.Mutex
+	dir     bool
+	loaded  bool
+	node    *TreeNode
+	watcher *FileSystemWatcher
+	watched bool
+	// listMux is held while the children are replaced or synced, so concurrent listings can't add an entry twice.
+	listMux sync.Mutex
+}
+
+// NewFileSystemNode creates a node for the file or directory at the path in the filesystem. Use "." for the root of
+// the filesystem.
+func NewFileSystemNode(fsys fs.FS, name string) (*TreeNode, error) {
+	if fsys == nil {
+		return nil, errors.New("filesystem must not be nil")
+	}
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewTreeNode(&FileSystemModel{FS: fsys, Path: name, dir: info.IsDir()}), nil
+}
+
+// IsDir returns whether the entry is a directory.
+func (m *FileSystemModel) IsDir() bool {
+	return m.dir
+}
+
+// Name returns the last element of the entry's path.
+func (m *FileSystemModel) Name() string {
+	return path.Base(m.Path)
+}
+
+// GetIconResource picks an icon from the theme based on whether the entry is a directory, and the file's extension.
+func (m *FileSystemModel) GetIconResource() fyne.Resource {
+	if m.dir {
+		if node := m.treeNode(); node != nil && node.IsExpanded() {
+			return theme.FolderOpenIcon()
+		}
+		return theme.FolderIcon()
+	}
+	return fileIcon(m.Path)
+}
+
+// GetText returns the entry's name.
+func (m *FileSystemModel) GetText() string {
+	return m.Name()
+}
+
+// GetKey returns the entry's path, so expansion state can be restored after the tree is rebuilt.
+func (m *FileSystemModel) GetKey() string {
+	return m.Path
+}
+
+// SetTreeNode shows files as leaves, and lists a directory's entries before its node is first expanded. Expand and
+// condense hooks already set on the node are still called.
+func (m *FileSystemModel) SetTreeNode(node *TreeNode) {
+	m.mux.Lock()
+	m.node = node
+	m.mux.Unlock()
+	if !m.dir {
+		node.SetLeaf()
+		return
+	}
+	node.SetComparator(FoldersFirstCompare)
+	node.SetAutoSort(true)
+	beforeExpand, afterCondense := node.OnBeforeExpand, node.OnAfterCondense
+	node.OnBeforeExpand = func() {
+		if beforeExpand != nil {
+			beforeExpand()
+		}
+		m.mux.Lock()
+		loaded := m.loaded
+		m.loaded = true
+		m.mux.Unlock()
+		w := m.getWatcher()
+		var err error
+		if !loaded {
+			err = m.loadEntries()
+		} else if w != nil {
+			// Changes made while condensed weren't watched.
+			err = m.syncEntries(node)
+		}
+		if err != nil {
+			fyne.LogError("Unable to list directory", err)
+		}
+		m.setWatched(true)
+	}
+	node.OnAfterCondense = func() {
+		if afterCondense != nil {
+			afterCondense()
+		}
+		m.setWatched(false)
+	}
+}
+
+// Reload lists the directory's entries again, replacing its children. An error is returned if the entry isn't a
+// directory or it can't be listed.
+func (m *FileSystemModel) Reload() error {
+	if !m.dir {
+		return fmt.Errorf("%s is not a directory", m.Path)
+	}
+	m.mux.Lock()
+	m.loaded = true
+	node := m.node
+	m.mux.Unlock()
+	if node == nil {
+		return m.loadEntries()
+	}
+	var err error
+	node.batch(func() {
+		err = m.loadEntries()
+	})
+	return err
+}
+
+func (m *FileSystemModel) ownsChildren() bool {
+	return true
+}
+
+func (m *FileSystemModel) treeNode() *TreeNode {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.node
+}
+
+// loadEntries replaces the node's children with the directory's entries. An error node is shown if it can't be listed.
+func (m *FileSystemModel) loadEntries() error {
+	m.listMux.Lock()
+	defer m.listMux.Unlock()
+	node := m.treeNode()
+	if node == nil {
+		return errors.New("model is not bound to a node")
+	}
+	entries, err := fs.ReadDir(m.FS, m.Path)
+	for _, child := range node.Children() {
+		unwatchTree(child)
+	}
+	node.removeAllChildren()
+	if err != nil {
+		_ = node.Append(newPlaceholderNode(theme.ErrorIcon(), fmt.Sprintf("Failed to list: %v", err)))
+		return err
+	}
+	for _, entry := range entries {
+		if err := node.Append(m.newChild(entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newChild creates a node for an entry in the directory, watched by the directory's watcher.
+func (m *FileSystemModel) newChild(entry fs.DirEntry) *TreeNode {
+	return NewTreeNode(&FileSystemModel{
+		FS:      m.FS,
+		Path:    path.Join(m.Path, entry.Name()),
+		dir:     entry.IsDir(),
+		watcher: m.getWatcher(),
+	})
+}
+
+// FoldersFirstCompare sorts nodes with a FileSystemModel directory before files, then by NaturalCompare.
+func FoldersFirstCompare(a, b *TreeNode) int {
+	aDir, bDir := isDirNode(a), isDirNode(b)
+	if aDir != bDir {
+		if aDir {
+			return -1
+		}
+		return 1
+	}
+	return NaturalCompare(a, b)
+}
+
+func isDirNode(node *TreeNode) bool {
+	model, ok := node.model.(*FileSystemModel)
+	return ok && model.dir
+}
+
+// fileIconTypes lists the extensions shown with each of the theme's file icons.
+var fileIconTypes = []struct {
+	icon       func() fyne.Resource
+	extensions []string
+}{
+	{theme.FileImageIcon, []string{".bmp", ".gif", ".jpeg", ".jpg", ".png", ".svg", ".webp"}},
+	{theme.FileAudioIcon, []string{".flac", ".mp3", ".ogg", ".wav"}},
+	{theme.FileVideoIcon, []string{".avi", ".mkv", ".mov", ".mp4", ".webm"}},
+	{theme.FileTextIcon, []string{".csv", ".go", ".json", ".md", ".txt", ".xml", ".yaml", ".yml"}},
+	{theme.FileApplicationIcon, []string{".app", ".bin", ".exe", ".sh"}},
+}
+
+// fileIcon picks a theme icon for the file based on its extension.
+func fileIcon(name string) fyne.Resource {
+	ext := strings.ToLower(path.Ext(name))
+	for _, fileType := range fileIconTypes {
+		for _, candidate := range fileType.extensions {
+			if ext == candidate {
+				return fileType.icon()
+			}
+		}
+	}
+	return theme.FileIcon()
+}
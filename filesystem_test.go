@@ -0,0 +1,119 @@
+package fynetree
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"fyne.io/fyne/theme"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"b.txt":           {Data: []byte("b")},
+		"a10.png":         {Data: []byte("a10")},
+		"a2.png":          {Data: []byte("a2")},
+		"src/main.go":     {Data: []byte("package main")},
+		"docs/readme.md":  {Data: []byte("# Docs")},
+		"docs/guide/1.md": {Data: []byte("# Guide")},
+	}
+}
+
+func TestNewFileSystemNode(t *testing.T) {
+	if _, err := NewFileSystemNode(testFS(), "missing"); err == nil {
+		t.Fatalf("Expected error for a missing path")
+	}
+	file, err := NewFileSystemNode(testFS(), "b.txt")
+	if err != nil {
+		t.Fatalf("Failed to create file node: %v", err)
+	}
+	if !file.IsLeaf() || file.GetModelText() != "b.txt" || file.GetModelIconResource() != theme.FileTextIcon() {
+		t.Fatalf("Expected a leaf with a text file icon")
+	}
+	image, _ := NewFileSystemNode(testFS(), "a2.png")
+	if image.GetModelIconResource() != theme.FileImageIcon() {
+		t.Fatalf("Expected an image file icon")
+	}
+}
+
+func TestFileSystemModel_LazyListing(t *testing.T) {
+	containerSetup()
+	treeContainer.History = NewHistory()
+	root, err := NewFileSystemNode(testFS(), ".")
+	if err != nil {
+		t.Fatalf("Failed to create root node: %v", err)
+	}
+	if root.IsLeaf() || root.NumChildren() != 0 || root.GetModelIconResource() != theme.FolderIcon() {
+		t.Fatalf("Expected an unlisted branch with a folder icon")
+	}
+	_ = treeContainer.Append(root)
+	treeContainer.History.Clear()
+
+	root.Expand()
+	if got := joinedChildTexts(root); got != "docs,src,a2.png,a10.png,b.txt" {
+		t.Fatalf("Expected folders first in natural order, got %s", got)
+	}
+	if root.GetModelIconResource() != theme.FolderOpenIcon() {
+		t.Fatalf("Expected an open folder icon once expanded")
+	}
+	_ = treeContainer.Undo()
+	if root.IsExpanded() || root.NumChildren() != 5 {
+		t.Fatalf("Expected undo to condense the folder without removing listed entries")
+	}
+
+	docs := root.Children()[0]
+	if docs.Key() != "docs" || docs.NumChildren() != 0 {
+		t.Fatalf("Expected subdirectories to be listed lazily")
+	}
+	docs.Expand()
+	if got := joinedChildTexts(docs); got != "guide,readme.md" {
+		t.Fatalf("Expected docs to be listed, got %s", got)
+	}
+	if guide := docs.Children()[0]; guide.Key() != "docs/guide" {
+		t.Fatalf("Expected key to be the entry path, got '%s'", guide.Key())
+	}
+}
+
+func TestFileSystemModel_ChainsHooks(t *testing.T) {
+	root, _ := NewFileSystemNode(testFS(), ".")
+	var expanded, condensed bool
+	root.OnBeforeExpand = func() {
+		expanded = true
+	}
+	root.OnAfterCondense = func() {
+		condensed = true
+	}
+	root.model.SetTreeNode(root)
+
+	root.Expand()
+	if !expanded || root.NumChildren() != 5 {
+		t.Fatalf("Expected the previous hook to be called and the entries to be listed")
+	}
+	root.Condense()
+	if !condensed {
+		t.Fatalf("Expected the previous condense hook to be called")
+	}
+}
+
+func TestFileSystemModel_Reload(t *testing.T) {
+	fsys := testFS()
+	root, _ := NewFileSystemNode(fsys, "src")
+	root.Expand()
+	fsys["src/util.go"] = &fstest.MapFile{Data: []byte("package main")}
+	model := root.model.(*FileSystemModel)
+	if err := model.Reload(); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if got := joinedChildTexts(root); got != "main.go,util.go" {
+		t.Fatalf("Expected new file after reload, got %s", got)
+	}
+
+	delete(fsys, "src/main.go")
+	delete(fsys, "src/util.go")
+	if err := model.Reload(); err == nil || root.NumChildren() != 1 || !root.Children()[0].IsPlaceholder() {
+		t.Fatalf("Expected an error node when the directory can't be listed")
+	}
+	file, _ := NewFileSystemNode(testFS(), "b.txt")
+	if err := file.model.(*FileSystemModel).Reload(); err == nil {
+		t.Fatalf("Expected error reloading a file")
+	}
+}
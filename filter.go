@@ -0,0 +1,182 @@
+package fynetree
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NodeFilter is a predicate that decides whether a node matches a filter.
+type NodeFilter func(node *TreeNode) bool
+
+// SubstringFilter creates a NodeFilter that matches nodes with text containing the query, ignoring case.
+func SubstringFilter(query string) NodeFilter {
+	query = strings.ToLower(query)
+	return func(node *TreeNode) bool {
+		return strings.Contains(strings.ToLower(node.GetModelText()), query)
+	}
+}
+
+// FuzzyFilter creates a NodeFilter that matches nodes with text containing every character of the query in order,
+// ignoring case. For example, "fbr" matches "FooBar".
+func FuzzyFilter(query string) NodeFilter {
+	pattern := []rune(strings.ToLower(query))
+	return func(node *TreeNode) bool {
+		i := 0
+		for _, r := range node.GetModelText() {
+			if i == len(pattern) {
+				break
+			}
+			if unicode.ToLower(r) == pattern[i] {
+				i++
+			}
+		}
+		return i == len(pattern)
+	}
+}
+
+// SetFilter hides every row that doesn't match the filter and has no matching descendants, and expands the ancestors
+// of each match so it's shown. The nodes themselves aren't changed, and the expansion state from before the first
+// filter was applied is restored by ClearFilter. Expanding for a filter doesn't trigger OnBeforeExpand or load lazy
+// children, so only loaded nodes are matched. Passing a nil filter clears it.
+func (t *TreeContainer) SetFilter(filter NodeFilter) {
+	if filter == nil {
+		t.ClearFilter()
+		return
+	}
+	t.mux.Lock()
+	if t.filter == nil {
+		t.filterExpansion = make(map[*TreeNode]bool)
+		for _, root := range t.Children() {
+			saveExpansion(root, t.filterExpansion)
+		}
+	}
+	t.filter = filter
+	t.mux.Unlock()
+
+	t.invalidateVisible()
+	t.Batch(func() {
+		for _, root := range t.Children() {
+			expandMatches(root, filter)
+		}
+		t.cursorFiltered()
+		t.Refresh()
+	})
+}
+
+// ClearFilter shows every row again, and restores the expansion state from before the filter was applied.
+// Nodes added while the filter was applied keep their current expansion state.
+func (t *TreeContainer) ClearFilter() {
+	t.mux.Lock()
+	if t.filter == nil {
+		t.mux.Unlock()
+		return
+	}
+	expansion := t.filterExpansion
+	t.filter = nil
+	t.filterExpansion = nil
+	t.mux.Unlock()
+
+	t.invalidateVisible()
+	t.Batch(func() {
+		for node, expanded := range expansion {
+			if node.treeContainer() == t {
+				node.setExpanded(expanded)
+			}
+		}
+		t.cursorFiltered()
+		t.Refresh()
+	})
+}
+
+// IsFiltered returns whether a filter is currently applied.
+func (t *TreeContainer) IsFiltered() bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.filter != nil
+}
+
+func (t *TreeContainer) currentFilter() NodeFilter {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.filter
+}
+
+// cursorFiltered moves the cursor to the first visible row if the filter has hidden it.
+func (t *TreeContainer) cursorFiltered() {
+	cursor := t.FocusedNode()
+	if cursor == nil {
+		return
+	}
+	visible := t.visibleNodes()
+	if indexOfNode(visible, cursor) >= 0 {
+		return
+	}
+	if len(visible) == 0 {
+		t.setCursor(nil)
+	} else {
+		t.setCursor(visible[0])
+	}
+}
+
+// appendFiltered appends the node and its shown descendants if it or any of its descendants match the filter.
+func (n *TreeNode) appendFiltered(visible []*TreeNode, filter NodeFilter) ([]*TreeNode, bool) {
+	var children []*TreeNode
+	childMatched := false
+	for _, obj := range n.nodeList.Objects {
+		if child, ok := obj.(*TreeNode); ok {
+			var matched bool
+			children, matched = child.appendFiltered(children, filter)
+			childMatched = childMatched || matched
+		}
+	}
+	if !childMatched && !filter(n) {
+		return visible, false
+	}
+	visible = append(visible, n)
+	if n.IsBranch() && n.IsExpanded() {
+		visible = append(visible, children...)
+	}
+	return visible, true
+}
+
+// setExpanded changes the expansion state without triggering any hooks or refreshing.
+func (n *TreeNode) setExpanded(expanded bool) {
+	if n.IsLeaf() || n.expanded == expanded {
+		return
+	}
+	n.expanded = expanded
+	n.visibilityChanged()
+	if expanded {
+		n.showChildren()
+	} else {
+		n.hideChildren()
+	}
+}
+
+func saveExpansion(node *TreeNode, expansion map[*TreeNode]bool) {
+	if node.IsLeaf() {
+		return
+	}
+	expansion[node] = node.IsExpanded()
+	for _, obj := range node.nodeList.Objects {
+		if child, ok := obj.(*TreeNode); ok {
+			saveExpansion(child, expansion)
+		}
+	}
+}
+
+// expandMatches expands every node with a matching descendant, and returns whether the node or a descendant matched.
+func expandMatches(node *TreeNode, filter NodeFilter) bool {
+	childMatched := false
+	for _, obj := range node.nodeList.Objects {
+		if child, ok := obj.(*TreeNode); ok {
+			if expandMatches(child, filter) {
+				childMatched = true
+			}
+		}
+	}
+	if childMatched {
+		node.setExpanded(true)
+	}
+	return childMatched || filter(node)
+}
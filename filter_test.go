@@ -0,0 +1,105 @@
+package fynetree
+
+import "testing"
+
+func filterSetup() {
+	containerSetup()
+	_ = treeContainer.Append(nodeA)
+	_ = treeContainer.Append(nodeB)
+	_ = nodeB.Append(nodeC)
+	_ = nodeC.Append(nodeD)
+}
+
+func assertVisible(t *testing.T, want ...*TreeNode) {
+	t.Helper()
+	got := treeContainer.visibleNodes()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d visible rows, got %d", len(want), len(got))
+	}
+	for i, n := range want {
+		if got[i] != n {
+			t.Fatalf("Expected row %d to be '%s', got '%s'", i, n.GetModelText(), got[i].GetModelText())
+		}
+	}
+}
+
+func TestSubstringFilter(t *testing.T) {
+	node := NewTreeNode(NewStaticModel(nil, "Hello World"))
+	tests := map[string]bool{
+		"":      true,
+		"world": true,
+		"LO W":  true,
+		"wd":    false,
+	}
+	for query, want := range tests {
+		if got := SubstringFilter(query)(node); got != want {
+			t.Fatalf("Expected substring '%s' match to be %v", query, want)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	node := NewTreeNode(NewStaticModel(nil, "FooBar"))
+	tests := map[string]bool{
+		"":        true,
+		"fbr":     true,
+		"OOB":     true,
+		"foobar":  true,
+		"rb":      false,
+		"foobarz": false,
+	}
+	for query, want := range tests {
+		if got := FuzzyFilter(query)(node); got != want {
+			t.Fatalf("Expected fuzzy '%s' match to be %v", query, want)
+		}
+	}
+}
+
+func TestTreeContainer_SetFilter(t *testing.T) {
+	filterSetup()
+	assertVisible(t, nodeA, nodeB)
+
+	treeContainer.SetFilter(SubstringFilter("d"))
+	if !treeContainer.IsFiltered() {
+		t.Fatalf("Expected container to be filtered")
+	}
+	assertVisible(t, nodeB, nodeC, nodeD)
+	if treeContainer.NumRoots() != 2 || nodeB.NumChildren() != 1 {
+		t.Fatalf("Filter should not change the nodes")
+	}
+
+	treeContainer.SetFilter(SubstringFilter("a"))
+	assertVisible(t, nodeA)
+
+	treeContainer.ClearFilter()
+	if treeContainer.IsFiltered() {
+		t.Fatalf("Expected filter to be cleared")
+	}
+	assertVisible(t, nodeA, nodeB)
+	if nodeB.IsExpanded() || nodeC.IsExpanded() {
+		t.Fatalf("Expected expansion state to be restored")
+	}
+}
+
+func TestTreeContainer_ClearFilterKeepsExpansion(t *testing.T) {
+	filterSetup()
+	nodeB.Expand()
+	treeContainer.SetFilter(SubstringFilter("d"))
+	nodeB.Condense()
+	assertVisible(t, nodeB)
+
+	treeContainer.SetFilter(nil)
+	if !nodeB.IsExpanded() || nodeC.IsExpanded() {
+		t.Fatalf("Expected expansion state from before the filter to be restored")
+	}
+	assertVisible(t, nodeA, nodeB, nodeC)
+}
+
+func TestTreeContainer_FilterMovesCursor(t *testing.T) {
+	filterSetup()
+	treeContainer.FocusNode(nodeA)
+	treeContainer.SetFilter(SubstringFilter("d"))
+	if treeContainer.FocusedNode() != nodeB {
+		t.Fatalf("Expected cursor to move to the first visible row")
+	}
+}
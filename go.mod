@@ -1,5 +1,10 @@
 module github.com/drognisep/fynetree
 
-go 1.14
+go 1.16
 
-require fyne.io/fyne v1.4.1
+require (
+	fyne.io/fyne v1.4.1
+	golang.org/x/sys v0.0.0-20200720211630-cb9d2d5c5666
+	golang.org/x/text v0.3.2
+	gopkg.in/yaml.v2 v2.2.8
+)
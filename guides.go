@@ -0,0 +1,137 @@
+package fynetree
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/theme"
+)
+
+// guideSegment is a single horizontal or vertical piece of a guide line.
+type guideSegment struct {
+	pos  fyne.Position
+	size fyne.Size
+}
+
+func verticalGuide(x, top, bottom int) guideSegment {
+	return guideSegment{fyne.NewPos(x, top), fyne.NewSize(1, bottom-top)}
+}
+
+func horizontalGuide(left, right, y int) guideSegment {
+	return guideSegment{fyne.NewPos(left, y), fyne.NewSize(right-left, 1)}
+}
+
+// guideLines draws guide line segments, reusing the same rectangles each time they're laid out.
+type guideLines struct {
+	lines []*canvas.Rectangle
+}
+
+// layout shows a line for each segment and hides the rest.
+func (g *guideLines) layout(segments []guideSegment) {
+	for len(g.lines) < len(segments) {
+		g.lines = append(g.lines, canvas.NewRectangle(theme.DisabledTextColor()))
+	}
+	for i, line := range g.lines {
+		if i >= len(segments) {
+			line.Hide()
+			continue
+		}
+		line.FillColor = theme.DisabledTextColor()
+		line.Move(segments[i].pos)
+		line.Resize(segments[i].size)
+		line.Show()
+	}
+}
+
+func (g *guideLines) objects() []fyne.CanvasObject {
+	objects := make([]fyne.CanvasObject, len(g.lines))
+	for i, line := range g.lines {
+		objects[i] = line
+	}
+	return objects
+}
+
+// rowGuides returns the guide lines for a node's row in a TreeContainer. Each ancestor with a later sibling continues
+// its line through the row, and the node gets an elbow from its parent's line, which stops at the elbow if the node is
+// the last child. An expanded branch also gets a stub down to its first child. While a filter is applied, shown holds the
+// nodes it leaves shown, and hidden siblings and children don't count. A nil set means every node is shown.
+func rowGuides(node *TreeNode, depth int, style TreeStyle, height int, shown map[*TreeNode]bool) []guideSegment {
+	if !style.GuideLines {
+		return nil
+	}
+	var segments []guideSegment
+	middle := height / 2
+	center := style.IconSize / 2
+	if depth > 0 && node.parent != nil {
+		x := (depth-1)*style.Indent + center
+		bottom := middle
+		if hasShownSibling(node, shown) {
+			bottom = height
+		}
+		segments = append(segments, verticalGuide(x, 0, bottom))
+		right := depth * style.Indent
+		if node.IsLeaf() {
+			right += center
+		}
+		segments = append(segments, horizontalGuide(x, right, middle))
+	}
+	level := depth - 2
+	for ancestor := node.parent; ancestor != nil && ancestor.parent != nil && level >= 0; ancestor = ancestor.parent {
+		if hasShownSibling(ancestor, shown) {
+			segments = append(segments, verticalGuide(level*style.Indent+center, 0, height))
+		}
+		level--
+	}
+	if node.IsBranch() && node.IsExpanded() && hasShownChild(node, shown) {
+		segments = append(segments, verticalGuide(depth*style.Indent+center, (height+style.IconSize)/2, height))
+	}
+	return segments
+}
+
+// hasShownSibling returns whether any sibling after the node is in the shown set.
+func hasShownSibling(node *TreeNode, shown map[*TreeNode]bool) bool {
+	if shown == nil {
+		return node.NextSibling() != nil
+	}
+	if node.parent == nil {
+		return false
+	}
+	siblings := node.parent.nodeList.Children()
+	i := indexOfNode(siblings, node)
+	if i < 0 {
+		return false
+	}
+	for _, sibling := range siblings[i+1:] {
+		if shown[sibling] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasShownChild returns whether any of the node's children are in the shown set.
+func hasShownChild(node *TreeNode, shown map[*TreeNode]bool) bool {
+	if shown == nil {
+		return node.Len() > 0
+	}
+	for _, child := range node.nodeList.Children() {
+		if shown[child] {
+			return true
+		}
+	}
+	return false
+}
+
+// childGuides returns the guide lines joining a standalone node's row to its nested children, given the top of each
+// child and the height of their rows.
+func childGuides(style TreeStyle, rowHeight int, childTops []int) []guideSegment {
+	if !style.GuideLines || len(childTops) == 0 {
+		return nil
+	}
+	center := style.IconSize / 2
+	last := childTops[len(childTops)-1] + rowHeight/2
+	segments := []guideSegment{verticalGuide(center, (rowHeight+style.IconSize)/2, last)}
+	for _, top := range childTops {
+		segments = append(segments, horizontalGuide(center, style.Indent, top+rowHeight/2))
+	}
+	return segments
+}
@@ -0,0 +1,92 @@
+package fynetree
+
+import "testing"
+
+var guideStyle = TreeStyle{Indent: 20, IconSize: 10, GuideLines: true}.resolved()
+
+func assertSegments(t *testing.T, got []guideSegment, want ...guideSegment) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d segments, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected segment %d to be %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRowGuides(t *testing.T) {
+	traversalSetup()
+	nodeC.SetLeaf()
+	nodeD.SetLeaf()
+
+	if segments := rowGuides(nodeC, 2, TreeStyle{}.resolved(), 30, nil); segments != nil {
+		t.Fatalf("Expected no guides unless they're enabled")
+	}
+	if segments := rowGuides(rootNode, 0, guideStyle, 30, nil); len(segments) != 0 {
+		t.Fatalf("Expected no guides for a collapsed root, got %v", segments)
+	}
+
+	assertSegments(t, rowGuides(nodeC, 2, guideStyle, 30, nil),
+		verticalGuide(25, 0, 30), horizontalGuide(25, 45, 15), verticalGuide(5, 0, 30))
+	assertSegments(t, rowGuides(nodeD, 2, guideStyle, 30, nil),
+		verticalGuide(25, 0, 15), horizontalGuide(25, 45, 15), verticalGuide(5, 0, 30))
+
+	nodeA.Expand()
+	assertSegments(t, rowGuides(nodeA, 1, guideStyle, 30, nil),
+		verticalGuide(5, 0, 30), horizontalGuide(5, 20, 15), verticalGuide(25, 20, 30))
+	assertSegments(t, rowGuides(nodeB, 1, guideStyle, 30, nil),
+		verticalGuide(5, 0, 15), horizontalGuide(5, 20, 15))
+
+	onlyC := map[*TreeNode]bool{rootNode: true, nodeA: true, nodeC: true}
+	assertSegments(t, rowGuides(nodeC, 2, guideStyle, 30, onlyC),
+		verticalGuide(25, 0, 15), horizontalGuide(25, 45, 15))
+	assertSegments(t, rowGuides(nodeA, 1, guideStyle, 30, onlyC),
+		verticalGuide(5, 0, 15), horizontalGuide(5, 20, 15), verticalGuide(25, 20, 30))
+}
+
+func TestChildGuides(t *testing.T) {
+	assertSegments(t, childGuides(guideStyle, 30, []int{30, 60}),
+		verticalGuide(5, 20, 75), horizontalGuide(5, 20, 45), horizontalGuide(5, 20, 75))
+	if segments := childGuides(guideStyle, 30, nil); segments != nil {
+		t.Fatalf("Expected no guides without children")
+	}
+}
+
+// shownGuides counts the guide lines drawn in the node's row.
+func shownGuides(t *testing.T, node *TreeNode) int {
+	t.Helper()
+	row, ok := rowPart(treeContainer, node, RowPartRow).(*treeRow)
+	if !ok {
+		t.Fatalf("Expected a row to show '%s'", node.GetModelText())
+	}
+	var shown int
+	for _, line := range row.renderer.guides.lines {
+		if line.Visible() {
+			shown++
+		}
+	}
+	return shown
+}
+
+func TestTreeContainer_GuideLinesShown(t *testing.T) {
+	traversalSetup()
+	rootNode.Expand()
+	nodeA.Expand()
+	treeContainer.Style = TreeStyle{GuideLines: true}
+	w := showContainer()
+	defer w.Close()
+
+	if shown := shownGuides(t, nodeB); shown != 2 {
+		t.Fatalf("Expected an elbow for the last child, got %d lines", shown)
+	}
+	if shown := shownGuides(t, nodeC); shown != 3 {
+		t.Fatalf("Expected an elbow and A's line to B, got %d lines", shown)
+	}
+
+	treeContainer.SetFilter(SubstringFilter("C"))
+	if shown := shownGuides(t, nodeC); shown != 2 {
+		t.Fatalf("Expected no line to siblings hidden by the filter, got %d lines", shown)
+	}
+}
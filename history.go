@@ -0,0 +1,367 @@
+package fynetree
+
+import (
+	"errors"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"github.com/drognisep/fynetree/util"
+)
+
+// Command is a reversible change recorded in a History.
+type Command interface {
+	// Undo reverts the change.
+	Undo() error
+	// Redo applies the change again after it's been undone.
+	Redo() error
+}
+
+// History records the changes made to a TreeContainer so they can be undone and redone. Nodes are recorded as they're
+// added, removed, expanded, condensed or renamed. Children loaded from a ChildrenProvider or a FileSystemModel aren't
+// recorded, since the model owns them.
+type History struct {
+	// Limit is the most changes that are kept to be undone, or unlimited if it's 0.
+	Limit int
+	// OnChanged is called whenever a change is recorded, undone or redone, so controls can check CanUndo and CanRedo.
+	OnChanged func()
+
+	mux         sync.Mutex
+	undo        []Command
+	redo        []Command
+	transaction *transaction
+	depth       int
+	replaying   bool
+}
+
+// NewHistory creates an empty History.
+func NewHistory() *History {
+	return &History{}
+}
+
+// Record adds a change that has already been made to the history, and discards any changes that could be redone.
+// Changes made while a change is being undone or redone aren't recorded.
+func (h *History) Record(cmd Command) {
+	h.mux.Lock()
+	if h.replaying || cmd == nil {
+		h.mux.Unlock()
+		return
+	}
+	if h.transaction != nil {
+		h.transaction.commands = append(h.transaction.commands, cmd)
+		h.mux.Unlock()
+		return
+	}
+	h.push(cmd)
+	h.mux.Unlock()
+	h.changed()
+}
+
+func (h *History) push(cmd Command) {
+	h.undo = append(h.undo, cmd)
+	if h.Limit > 0 && len(h.undo) > h.Limit {
+		h.undo = h.undo[len(h.undo)-h.Limit:]
+	}
+	h.redo = nil
+}
+
+// BeginTransaction groups every change recorded until the matching EndTransaction, so they're undone and redone
+// together. Transactions may be nested, in which case they're grouped into the outermost one.
+func (h *History) BeginTransaction() {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	if h.depth == 0 {
+		h.transaction = &transaction{}
+	}
+	h.depth++
+}
+
+// EndTransaction ends a transaction started with BeginTransaction, recording its changes as a single change.
+func (h *History) EndTransaction() {
+	h.mux.Lock()
+	if h.depth == 0 {
+		h.mux.Unlock()
+		return
+	}
+	h.depth--
+	if h.depth > 0 {
+		h.mux.Unlock()
+		return
+	}
+	tx := h.transaction
+	h.transaction = nil
+	if len(tx.commands) == 0 {
+		h.mux.Unlock()
+		return
+	}
+	h.push(tx)
+	h.mux.Unlock()
+	h.changed()
+}
+
+// Transaction calls the function inside a transaction.
+func (h *History) Transaction(fn func()) {
+	h.BeginTransaction()
+	defer h.EndTransaction()
+	fn()
+}
+
+// CanUndo returns whether there's a change that can be undone.
+func (h *History) CanUndo() bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	return len(h.undo) > 0 && h.transaction == nil
+}
+
+// CanRedo returns whether there's an undone change that can be redone.
+func (h *History) CanRedo() bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	return len(h.redo) > 0 && h.transaction == nil
+}
+
+// Undo reverts the most recent change. An error is returned if there's nothing to undo, a transaction is in progress,
+// or the change couldn't be reverted, in which case it's kept to be undone again.
+func (h *History) Undo() error {
+	return h.replay(&h.undo, &h.redo, Command.Undo)
+}
+
+// Redo applies the most recently undone change again. An error is returned if there's nothing to redo, a transaction
+// is in progress, or the change couldn't be applied, in which case it's kept to be redone again.
+func (h *History) Redo() error {
+	return h.replay(&h.redo, &h.undo, Command.Redo)
+}
+
+// Clear discards every recorded change.
+func (h *History) Clear() {
+	h.mux.Lock()
+	h.undo = nil
+	h.redo = nil
+	h.mux.Unlock()
+	h.changed()
+}
+
+// replay pops a change from one stack, applies it, and pushes it onto the other. A change that fails to apply is put
+// back onto the stack it came from.
+func (h *History) replay(from, to *[]Command, apply func(Command) error) error {
+	h.mux.Lock()
+	if h.transaction != nil {
+		h.mux.Unlock()
+		return errors.New("unable to replay changes during a transaction")
+	}
+	if len(*from) == 0 {
+		h.mux.Unlock()
+		return errors.New("no changes to replay")
+	}
+	cmd := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	h.replaying = true
+	h.mux.Unlock()
+
+	err := apply(cmd)
+
+	h.mux.Lock()
+	h.replaying = false
+	if err != nil {
+		*from = append(*from, cmd)
+		h.mux.Unlock()
+		return err
+	}
+	*to = append(*to, cmd)
+	h.mux.Unlock()
+	h.changed()
+	return nil
+}
+
+func (h *History) changed() {
+	if h.OnChanged != nil {
+		h.OnChanged()
+	}
+}
+
+// transaction is a group of changes that are undone and redone together.
+type transaction struct {
+	commands []Command
+}
+
+// Undo reverts the changes in reverse order. If one fails, the changes already reverted are applied again so the
+// transaction is left as it was and can be undone again later.
+func (t *transaction) Undo() error {
+	for i := len(t.commands) - 1; i >= 0; i-- {
+		if err := t.commands[i].Undo(); err != nil {
+			for _, cmd := range t.commands[i+1:] {
+				_ = cmd.Redo()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Redo applies the changes in order. If one fails, the changes already applied are reverted again so the transaction
+// is left as it was and can be redone again later.
+func (t *transaction) Redo() error {
+	for i, cmd := range t.commands {
+		if err := cmd.Redo(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				_ = t.commands[j].Undo()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// insertCommand records a node being added to a list.
+type insertCommand struct {
+	list     *nodeList
+	node     *TreeNode
+	position int
+}
+
+func (c *insertCommand) Undo() error {
+	_, err := c.list.Remove(c.node)
+	return err
+}
+
+func (c *insertCommand) Redo() error {
+	return c.list.insertAt(util.IntMin(c.position, c.list.Len()), c.node)
+}
+
+// removeCommand records a node being removed from a list.
+type removeCommand struct {
+	insertCommand
+}
+
+func (c *removeCommand) Undo() error {
+	return c.insertCommand.Redo()
+}
+
+func (c *removeCommand) Redo() error {
+	return c.insertCommand.Undo()
+}
+
+// expandCommand records a node being expanded or condensed.
+type expandCommand struct {
+	node     *TreeNode
+	expanded bool
+}
+
+func (c *expandCommand) Undo() error {
+	c.setExpanded(!c.expanded)
+	return nil
+}
+
+func (c *expandCommand) Redo() error {
+	c.setExpanded(c.expanded)
+	return nil
+}
+
+func (c *expandCommand) setExpanded(expanded bool) {
+	if expanded {
+		c.node.Expand()
+	} else {
+		c.node.Condense()
+	}
+}
+
+// renameCommand records a node being renamed.
+type renameCommand struct {
+	node    *TreeNode
+	oldText string
+	newText string
+}
+
+func (c *renameCommand) Undo() error {
+	return c.setText(c.oldText)
+}
+
+func (c *renameCommand) Redo() error {
+	return c.setText(c.newText)
+}
+
+func (c *renameCommand) setText(text string) error {
+	model, ok := c.node.model.(EditableModel)
+	if !ok {
+		return errors.New("node model is not editable")
+	}
+	if err := model.SetText(text); err != nil {
+		return err
+	}
+	c.node.Refresh()
+	return nil
+}
+
+// record adds the change to the container's history, if it has one.
+func (t *TreeContainer) record(cmd Command) {
+	if t.History != nil {
+		t.History.Record(cmd)
+	}
+}
+
+// childrenOwner is implemented by models that manage their node's children themselves, such as FileSystemModel.
+type childrenOwner interface {
+	ownsChildren() bool
+}
+
+// history gets the history that changes to this node's children are recorded in, or nil if they aren't recorded.
+func (n *TreeNode) history() *History {
+	if _, ok := n.model.(ChildrenProvider); ok {
+		return nil
+	}
+	if owner, ok := n.model.(childrenOwner); ok && owner.ownsChildren() {
+		return nil
+	}
+	if c := n.treeContainer(); c != nil {
+		return c.History
+	}
+	return nil
+}
+
+// recordChange records a node being added to or removed from the list, unless it's a placeholder.
+func (n *nodeList) recordChange(node *TreeNode, position int, added bool) {
+	if n.history == nil || node == nil || node.IsPlaceholder() {
+		return
+	}
+	history := n.history()
+	if history == nil {
+		return
+	}
+	insert := insertCommand{list: n, node: node, position: position}
+	if added {
+		history.Record(&insert)
+	} else {
+		history.Record(&removeCommand{insert})
+	}
+}
+
+// Undo reverts the most recent change in the container's history.
+func (t *TreeContainer) Undo() error {
+	if t.History == nil {
+		return errors.New("container has no history")
+	}
+	return t.History.Undo()
+}
+
+// Redo applies the most recently undone change in the container's history again.
+func (t *TreeContainer) Redo() error {
+	if t.History == nil {
+		return errors.New("container has no history")
+	}
+	return t.History.Redo()
+}
+
+// TypedShortcut undoes changes with ctrl+Z, and redoes them with ctrl+Y or ctrl+shift+Z.
+func (t *TreeContainer) TypedShortcut(shortcut fyne.Shortcut) {
+	custom, ok := shortcut.(*desktop.CustomShortcut)
+	if !ok || t.History == nil {
+		return
+	}
+	switch {
+	case custom.KeyName == fyne.KeyZ && custom.Modifier == desktop.ControlModifier:
+		_ = t.History.Undo()
+	case custom.KeyName == fyne.KeyY && custom.Modifier == desktop.ControlModifier,
+		custom.KeyName == fyne.KeyZ && custom.Modifier == desktop.ControlModifier|desktop.ShiftModifier:
+		_ = t.History.Redo()
+	}
+}
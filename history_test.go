@@ -0,0 +1,204 @@
+package fynetree
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+)
+
+// historySetup builds root -> (A -> (C, D), B) in a container, then starts recording its history.
+func historySetup() *History {
+	traversalSetup()
+	history := NewHistory()
+	treeContainer.History = history
+	return history
+}
+
+func TestHistory_UndoRedoStructure(t *testing.T) {
+	history := historySetup()
+	if history.CanUndo() || history.CanRedo() {
+		t.Fatalf("Expected a new history to be empty")
+	}
+
+	newNode := NewTreeNode(NewStaticModel(nil, "E"))
+	_ = nodeA.InsertAt(1, newNode)
+	_, _ = rootNode.Remove(nodeB)
+	if !history.CanUndo() || joinedChildTexts(nodeA) != "C,E,D" || joinedChildTexts(rootNode) != "A" {
+		t.Fatalf("Expected changes to be made and recorded")
+	}
+
+	if err := history.Undo(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if joinedChildTexts(rootNode) != "A,B" || nodeB.GetParent() != rootNode {
+		t.Fatalf("Expected removal to be undone, got %s", joinedChildTexts(rootNode))
+	}
+	_ = history.Undo()
+	if joinedChildTexts(nodeA) != "C,D" || history.CanUndo() || !history.CanRedo() {
+		t.Fatalf("Expected insertion to be undone, got %s", joinedChildTexts(nodeA))
+	}
+
+	_ = history.Redo()
+	_ = history.Redo()
+	if joinedChildTexts(nodeA) != "C,E,D" || joinedChildTexts(rootNode) != "A" || history.CanRedo() {
+		t.Fatalf("Expected changes to be redone")
+	}
+	if err := history.Redo(); err == nil {
+		t.Fatalf("Expected error when there's nothing to redo")
+	}
+}
+
+func TestHistory_Transaction(t *testing.T) {
+	history := historySetup()
+	var changes int
+	history.OnChanged = func() {
+		changes++
+	}
+
+	history.Transaction(func() {
+		_ = treeContainer.MoveNode(nodeC, nodeB, DropInto)
+		nodeA.Condense()
+		if history.CanUndo() {
+			t.Fatalf("Expected undo to be unavailable during a transaction")
+		}
+	})
+	if changes != 1 || joinedChildTexts(nodeB) != "C" || !nodeB.IsExpanded() {
+		t.Fatalf("Expected a single change to be recorded, got %d", changes)
+	}
+
+	_ = history.Undo()
+	if joinedChildTexts(nodeA) != "C,D" || nodeB.NumChildren() != 0 || nodeB.IsExpanded() {
+		t.Fatalf("Expected the whole transaction to be undone, got %s", joinedChildTexts(nodeA))
+	}
+	if history.CanUndo() {
+		t.Fatalf("Expected nothing else to undo")
+	}
+
+	history.Limit = 1
+	nodeA.Expand()
+	nodeB.Expand()
+	_ = history.Undo()
+	if history.CanUndo() || !nodeA.IsExpanded() {
+		t.Fatalf("Expected history to be limited to one change")
+	}
+}
+
+type failingCommand struct {
+	err error
+}
+
+func (c *failingCommand) Undo() error {
+	return c.err
+}
+
+func (c *failingCommand) Redo() error {
+	return c.err
+}
+
+func TestHistory_FailedUndo(t *testing.T) {
+	history := NewHistory()
+	cmd := &failingCommand{err: errors.New("failed")}
+	history.Record(cmd)
+	if err := history.Undo(); err == nil {
+		t.Fatalf("Expected the failure to be returned")
+	}
+	if !history.CanUndo() || history.CanRedo() {
+		t.Fatalf("Expected a change that failed to undo to stay on the undo stack")
+	}
+
+	cmd.err = nil
+	_ = history.Undo()
+	cmd.err = errors.New("failed")
+	_ = history.Redo()
+	if history.CanUndo() || !history.CanRedo() {
+		t.Fatalf("Expected a change that failed to redo to stay on the redo stack")
+	}
+}
+
+// countingCommand counts how many times it's currently applied.
+type countingCommand struct {
+	applied *int
+}
+
+func (c *countingCommand) Undo() error {
+	*c.applied--
+	return nil
+}
+
+func (c *countingCommand) Redo() error {
+	*c.applied++
+	return nil
+}
+
+func TestHistory_FailedTransaction(t *testing.T) {
+	history := NewHistory()
+	applied := 2
+	failing := &failingCommand{err: errors.New("failed")}
+	history.Transaction(func() {
+		history.Record(&countingCommand{&applied})
+		history.Record(failing)
+		history.Record(&countingCommand{&applied})
+	})
+
+	if err := history.Undo(); err == nil {
+		t.Fatalf("Expected the failure to be returned")
+	}
+	if applied != 2 || !history.CanUndo() {
+		t.Fatalf("Expected the transaction to be rolled back, %d changes applied", applied)
+	}
+	failing.err = nil
+	if err := history.Undo(); err != nil || applied != 0 {
+		t.Fatalf("Expected retrying the undo to revert every change once, %d changes applied", applied)
+	}
+
+	failing.err = errors.New("failed")
+	if err := history.Redo(); err == nil {
+		t.Fatalf("Expected the failure to be returned")
+	}
+	if applied != 0 || !history.CanRedo() {
+		t.Fatalf("Expected the transaction to be rolled back, %d changes applied", applied)
+	}
+	failing.err = nil
+	if err := history.Redo(); err != nil || applied != 2 {
+		t.Fatalf("Expected retrying the redo to apply every change once, %d changes applied", applied)
+	}
+}
+
+func TestHistory_Rename(t *testing.T) {
+	node, w := renameSetup()
+	defer w.Close()
+	treeContainer.History = NewHistory()
+
+	_ = treeContainer.StartRename(node)
+	typeRename(w, "After", fyne.KeyReturn)
+	if err := treeContainer.Undo(); err != nil || node.GetModelText() != "Before" {
+		t.Fatalf("Expected rename to be undone, got '%s'", node.GetModelText())
+	}
+	_ = treeContainer.Redo()
+	if node.GetModelText() != "After" {
+		t.Fatalf("Expected rename to be redone, got '%s'", node.GetModelText())
+	}
+}
+
+func TestTreeContainer_HistoryShortcuts(t *testing.T) {
+	history := historySetup()
+	_, _ = nodeA.Remove(nodeD)
+
+	treeContainer.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier})
+	if joinedChildTexts(nodeA) != "C,D" {
+		t.Fatalf("Expected ctrl+Z to undo")
+	}
+	treeContainer.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyY, Modifier: desktop.ControlModifier})
+	if joinedChildTexts(nodeA) != "C" {
+		t.Fatalf("Expected ctrl+Y to redo")
+	}
+	treeContainer.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier})
+	treeContainer.TypedShortcut(&desktop.CustomShortcut{
+		KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier | desktop.ShiftModifier,
+	})
+	if joinedChildTexts(nodeA) != "C" || !history.CanUndo() {
+		t.Fatalf("Expected ctrl+shift+Z to redo")
+	}
+}
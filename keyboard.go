@@ -0,0 +1,211 @@
+package fynetree
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+)
+
+// FocusGained is called by the canvas when the container gains keyboard focus.
+func (t *TreeContainer) FocusGained() {
+	t.mux.Lock()
+	t.focused = true
+	cursor := t.cursor
+	if cursor == nil && len(t.selected) > 0 {
+		cursor = t.selected[len(t.selected)-1]
+	}
+	t.mux.Unlock()
+	if cursor == nil {
+		if visible := t.visibleNodes(); len(visible) > 0 {
+			cursor = visible[0]
+		}
+	}
+	t.setCursor(cursor)
+	t.Refresh()
+}
+
+// FocusLost is called by the canvas when the container loses keyboard focus.
+func (t *TreeContainer) FocusLost() {
+	t.mux.Lock()
+	t.focused = false
+	t.shiftHeld = false
+	cursor := t.cursor
+	t.mux.Unlock()
+	if cursor != nil {
+		t.Refresh()
+	}
+}
+
+// Focused returns whether the container currently has keyboard focus.
+func (t *TreeContainer) Focused() bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.focused
+}
+
+func (t *TreeContainer) TypedRune(_ rune) {
+}
+
+// TypedKey handles navigation keys while the container has focus.
+func (t *TreeContainer) TypedKey(ev *fyne.KeyEvent) {
+	cursor := t.FocusedNode()
+	if cursor == nil {
+		t.FocusGained()
+		return
+	}
+	switch ev.Name {
+	case fyne.KeyUp:
+		t.moveCursor(-1)
+	case fyne.KeyDown:
+		t.moveCursor(1)
+	case fyne.KeyHome:
+		if visible := t.visibleNodes(); len(visible) > 0 {
+			t.navigateTo(visible[0])
+		}
+	case fyne.KeyEnd:
+		if visible := t.visibleNodes(); len(visible) > 0 {
+			t.navigateTo(visible[len(visible)-1])
+		}
+	case fyne.KeyLeft:
+		if cursor.IsBranch() && cursor.IsExpanded() {
+			cursor.Condense()
+		} else if parent := cursor.GetParent(); parent != nil {
+			t.navigateTo(parent)
+		}
+	case fyne.KeyRight:
+		if cursor.IsBranch() && cursor.IsCondensed() {
+			cursor.Expand()
+		} else if cursor.IsExpanded() {
+			if child := t.firstVisibleChild(cursor); child != nil {
+				t.navigateTo(child)
+			}
+		}
+	case fyne.KeySpace:
+		if t.Checkboxes {
+			cursor.ToggleChecked()
+		} else if t.SelectionMode == SelectionMulti && t.IsSelected(cursor) {
+			t.Deselect(cursor)
+		} else {
+			t.Select(cursor)
+		}
+	case fyne.KeyReturn, fyne.KeyEnter:
+		cursor.Activate()
+	case fyne.KeyF2:
+		if t.InlineRename {
+			_ = t.StartRename(cursor)
+		}
+	}
+}
+
+// KeyDown tracks whether shift is held so arrow keys can extend the selection.
+func (t *TreeContainer) KeyDown(ev *fyne.KeyEvent) {
+	if ev.Name == desktop.KeyShiftLeft || ev.Name == desktop.KeyShiftRight {
+		t.mux.Lock()
+		t.shiftHeld = true
+		t.mux.Unlock()
+	}
+}
+
+func (t *TreeContainer) KeyUp(ev *fyne.KeyEvent) {
+	if ev.Name == desktop.KeyShiftLeft || ev.Name == desktop.KeyShiftRight {
+		t.mux.Lock()
+		t.shiftHeld = false
+		t.mux.Unlock()
+	}
+}
+
+// FocusedNode returns the node with the keyboard cursor, or nil if there isn't one.
+func (t *TreeContainer) FocusedNode() *TreeNode {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.cursor
+}
+
+// FocusNode moves the keyboard cursor to the given node and requests keyboard focus for the container.
+func (t *TreeContainer) FocusNode(node *TreeNode) {
+	if node == nil || node.treeContainer() != t {
+		return
+	}
+	t.setCursor(node)
+	t.requestFocus()
+}
+
+// moveCursor moves the cursor by delta visible rows, clamping to the first and last row.
+func (t *TreeContainer) moveCursor(delta int) {
+	visible := t.visibleNodes()
+	if len(visible) == 0 {
+		return
+	}
+	i := indexOfNode(visible, t.FocusedNode()) + delta
+	if i < 0 {
+		i = 0
+	} else if i >= len(visible) {
+		i = len(visible) - 1
+	}
+	t.navigateTo(visible[i])
+}
+
+// firstVisibleChild returns the node's first child that's shown, or nil if the filter hides all of them. Visible rows
+// are in display order, so it's the row after the node if that row is one of its children.
+func (t *TreeContainer) firstVisibleChild(node *TreeNode) *TreeNode {
+	visible := t.visibleNodes()
+	i := indexOfNode(visible, node) + 1
+	if i <= 0 || i >= len(visible) || visible[i].GetParent() != node {
+		return nil
+	}
+	return visible[i]
+}
+
+// navigateTo moves the cursor to the node and updates the selection the same way a tap would.
+func (t *TreeContainer) navigateTo(node *TreeNode) {
+	var modifier desktop.Modifier
+	t.mux.Lock()
+	if t.shiftHeld {
+		modifier = desktop.ShiftModifier
+		if t.anchor == nil {
+			t.anchor = t.cursor
+		}
+	}
+	t.mux.Unlock()
+	t.setCursor(node)
+	t.nodeTapped(node, modifier)
+}
+
+func (t *TreeContainer) setCursor(node *TreeNode) {
+	t.mux.Lock()
+	previous := t.cursor
+	t.cursor = node
+	t.mux.Unlock()
+	if previous == node {
+		return
+	}
+	if node != nil {
+		t.viewport.scrollTo(node)
+	}
+	t.Refresh()
+}
+
+// pruneCursor clears the cursor if it's on the removed node or one of its descendants.
+func (t *TreeContainer) pruneCursor(removed *TreeNode) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.cursor == removed || (t.cursor != nil && t.cursor.isDescendantOf(removed)) {
+		t.cursor = nil
+	}
+}
+
+// nodeCondensed moves the cursor up to the condensed node if it was on one of its now hidden descendants.
+func (t *TreeContainer) nodeCondensed(node *TreeNode) {
+	if cursor := t.FocusedNode(); cursor != nil && cursor.isDescendantOf(node) {
+		t.setCursor(node)
+	}
+}
+
+func (t *TreeContainer) requestFocus() {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return
+	}
+	if c := app.Driver().CanvasForObject(t); c != nil && c.Focused() != t {
+		c.Focus(t)
+	}
+}
@@ -0,0 +1,133 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/test"
+)
+
+func typeKey(name fyne.KeyName) {
+	treeContainer.TypedKey(&fyne.KeyEvent{Name: name})
+}
+
+func assertCursor(t *testing.T, want *TreeNode) {
+	t.Helper()
+	if got := treeContainer.FocusedNode(); got != want {
+		t.Fatalf("Expected cursor on '%s', got %v", want.GetModelText(), got)
+	}
+	if !want.HasFocus() {
+		t.Fatalf("Expected node '%s' to report focus", want.GetModelText())
+	}
+}
+
+func TestTreeContainer_KeyboardNavigation(t *testing.T) {
+	selectionSetup()
+	testApp := test.NewApp()
+	win := testApp.NewWindow("Keyboard")
+	win.SetContent(treeContainer)
+	defer win.Close()
+
+	tapWithModifier(nodeA, 0)
+	if win.Canvas().Focused() != treeContainer {
+		t.Fatalf("Tapping a node should focus the container")
+	}
+	assertCursor(t, nodeA)
+
+	typeKey(fyne.KeyDown)
+	assertCursor(t, nodeB)
+	assertSelection(t, nodeB)
+
+	typeKey(fyne.KeyRight)
+	assertCursor(t, nodeD)
+
+	typeKey(fyne.KeyLeft)
+	assertCursor(t, nodeB)
+
+	typeKey(fyne.KeyLeft)
+	if nodeB.IsExpanded() {
+		t.Fatalf("Left on an expanded node should condense it")
+	}
+
+	typeKey(fyne.KeyRight)
+	if nodeB.IsCondensed() {
+		t.Fatalf("Right on a condensed node should expand it")
+	}
+
+	typeKey(fyne.KeyRight)
+	nodeB.Condense()
+	assertCursor(t, nodeB)
+
+	typeKey(fyne.KeyEnd)
+	assertCursor(t, nodeC)
+
+	typeKey(fyne.KeyHome)
+	assertCursor(t, nodeA)
+	assertSelection(t, nodeA)
+
+	typeKey(fyne.KeyUp)
+	assertCursor(t, nodeA)
+}
+
+func TestTreeContainer_KeyboardExtendSelection(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionMulti
+	treeContainer.FocusGained()
+	assertCursor(t, nodeA)
+
+	treeContainer.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	typeKey(fyne.KeyDown)
+	typeKey(fyne.KeyDown)
+	treeContainer.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	assertSelection(t, nodeA, nodeB, nodeD)
+
+	typeKey(fyne.KeyDown)
+	assertSelection(t, nodeC)
+
+	treeContainer.FocusLost()
+	if nodeC.HasFocus() {
+		t.Fatalf("Node should not report focus after the container loses it")
+	}
+}
+
+func TestTreeContainer_KeyboardActivate(t *testing.T) {
+	selectionSetup()
+	var activated int
+	nodeA.OnActivated = func() {
+		activated++
+	}
+	treeContainer.FocusNode(nodeA)
+	treeContainer.FocusGained()
+
+	typeKey(fyne.KeyReturn)
+	if activated != 1 {
+		t.Fatalf("Expected OnActivated to be called once, got %d", activated)
+	}
+
+	treeContainer.FocusNode(nodeB)
+	typeKey(fyne.KeyEnter)
+	if nodeB.IsExpanded() {
+		t.Fatalf("Activating a branch without a handler should toggle its expand state")
+	}
+}
+
+func TestTreeContainer_KeyboardRightFiltered(t *testing.T) {
+	traversalSetup()
+	rootNode.Expand()
+	nodeA.Expand()
+	treeContainer.SetFilter(func(node *TreeNode) bool {
+		return node == nodeA || node == nodeD
+	})
+	treeContainer.FocusNode(nodeA)
+
+	typeKey(fyne.KeyRight)
+	assertCursor(t, nodeD)
+
+	treeContainer.SetFilter(func(node *TreeNode) bool {
+		return node == nodeA
+	})
+	treeContainer.FocusNode(nodeA)
+	typeKey(fyne.KeyRight)
+	assertCursor(t, nodeA)
+}
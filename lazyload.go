@@ -0,0 +1,159 @@
+package fynetree
+
+import (
+	"fmt"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+)
+
+const loadingText = "Loading…"
+
+type loadState int
+
+const (
+	loadStateNotLoaded loadState = iota
+	loadStateLoading
+	loadStateLoaded
+	loadStateFailed
+)
+
+// IsLoading returns whether this node is currently waiting on its ChildrenProvider model.
+func (n *TreeNode) IsLoading() bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.loadState == loadStateLoading
+}
+
+// IsPlaceholder returns whether this node is a temporary row shown while its parent's children are loading, or after loading failed.
+func (n *TreeNode) IsPlaceholder() bool {
+	return n.placeholder
+}
+
+// ReloadChildren discards the children of a node with a ChildrenProvider model and loads them again.
+// Children are loaded immediately if the node is expanded, otherwise they'll be loaded the next time it's expanded.
+// The results of a load that's still in progress are discarded.
+func (n *TreeNode) ReloadChildren() {
+	provider, ok := n.model.(ChildrenProvider)
+	if !ok {
+		return
+	}
+	n.cancelLoad()
+	n.mux.Lock()
+	n.loadState = loadStateNotLoaded
+	n.mux.Unlock()
+
+	n.loadMux.Lock()
+	n.removeAllChildren()
+	n.loadMux.Unlock()
+	if provider.HasChildren() {
+		n.SetBranch()
+		if n.IsExpanded() {
+			if provider, generation := n.prepareLoad(); provider != nil {
+				go n.loadChildren(provider, generation)
+			}
+		}
+	} else {
+		n.SetLeaf()
+	}
+}
+
+// cancelLoad discards the results of a load in progress, so the children are loaded again the next time the node is
+// expanded.
+func (n *TreeNode) cancelLoad() {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.loadGeneration++
+	if n.loadState == loadStateLoading {
+		n.loadState = loadStateNotLoaded
+	}
+}
+
+// prepareLoad shows a loading placeholder the first time a ChildrenProvider node is expanded, and returns the provider
+// to load from with the generation identifying the load. Nil is returned if there's nothing to load.
+func (n *TreeNode) prepareLoad() (ChildrenProvider, int) {
+	provider, ok := n.model.(ChildrenProvider)
+	if !ok {
+		return nil, 0
+	}
+	n.mux.Lock()
+	if n.loadState != loadStateNotLoaded {
+		n.mux.Unlock()
+		return nil, 0
+	}
+	n.loadState = loadStateLoading
+	n.loadGeneration++
+	generation := n.loadGeneration
+	n.mux.Unlock()
+	n.loadMux.Lock()
+	n.setPlaceholder(newPlaceholderNode(theme.ViewRefreshIcon(), loadingText))
+	n.loadMux.Unlock()
+	return provider, generation
+}
+
+// loadChildren loads the children in the background and shows them, unless the load was cancelled or replaced by
+// another one in the meantime.
+func (n *TreeNode) loadChildren(provider ChildrenProvider, generation int) {
+	children, err := provider.LoadChildren()
+	n.loadMux.Lock()
+	defer n.loadMux.Unlock()
+	n.mux.Lock()
+	current := n.loadGeneration == generation
+	n.mux.Unlock()
+	if !current {
+		return
+	}
+	n.batch(func() {
+		n.showChildrenLoaded(children, err)
+	})
+}
+
+// showChildrenLoaded replaces the loading placeholder with the loaded children, or an error placeholder if loading failed.
+func (n *TreeNode) showChildrenLoaded(children []*TreeNode, err error) {
+	n.removeAllChildren()
+	if err != nil {
+		errorNode := newPlaceholderNode(theme.ErrorIcon(), fmt.Sprintf("Failed to load: %v (activate to retry)", err))
+		errorNode.OnActivated = n.ReloadChildren
+		errorNode.OnDoubleTapped = func(_ *fyne.PointEvent) {
+			n.ReloadChildren()
+		}
+		n.setPlaceholder(errorNode)
+		n.mux.Lock()
+		n.loadState = loadStateFailed
+		n.mux.Unlock()
+		return
+	}
+
+	for _, c := range children {
+		if err := n.Append(c); err != nil {
+			fyne.LogError("Unable to append loaded child", err)
+		}
+	}
+	n.mux.Lock()
+	n.loadState = loadStateLoaded
+	n.mux.Unlock()
+}
+
+func (n *TreeNode) setPlaceholder(placeholder *TreeNode) {
+	n.removeAllChildren()
+	if err := n.Append(placeholder); err != nil {
+		fyne.LogError("Unable to show placeholder", err)
+	}
+}
+
+func (n *TreeNode) removeAllChildren() {
+	n.batch(func() {
+		for n.Len() > 0 {
+			if _, err := n.RemoveAt(n.Len() - 1); err != nil {
+				fyne.LogError("Unable to remove child", err)
+				return
+			}
+		}
+	})
+}
+
+func newPlaceholderNode(resource fyne.Resource, text string) *TreeNode {
+	placeholder := NewLeafTreeNode(NewStaticModel(resource, text))
+	placeholder.placeholder = true
+	return placeholder
+}
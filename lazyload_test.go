@@ -0,0 +1,177 @@
+package fynetree
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne"
+)
+
+type lazyModel struct {
+	StaticNodeModel
+	children []string
+	fail     bool
+	loads    int
+	release  chan struct{}
+}
+
+func (l *lazyModel) HasChildren() bool {
+	return len(l.children) > 0
+}
+
+func (l *lazyModel) LoadChildren() ([]*TreeNode, error) {
+	<-l.release
+	l.loads++
+	if l.fail {
+		return nil, errors.New("unavailable")
+	}
+	var nodes []*TreeNode
+	for _, c := range l.children {
+		nodes = append(nodes, NewLeafTreeNode(NewStaticModel(nil, c)))
+	}
+	return nodes, nil
+}
+
+func newLazyModel(children ...string) *lazyModel {
+	return &lazyModel{
+		StaticNodeModel: StaticNodeModel{Text: "lazy"},
+		children:        children,
+		release:         make(chan struct{}, 1),
+	}
+}
+
+func waitForLoad(t *testing.T, node *TreeNode) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for node.IsLoading() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for children to load")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func childTexts(node *TreeNode) []string {
+	var texts []string
+	for _, obj := range node.Objects {
+		texts = append(texts, obj.(*TreeNode).GetModelText())
+	}
+	return texts
+}
+
+func TestTreeNode_LazyLoad(t *testing.T) {
+	model := newLazyModel("one", "two")
+	node := NewTreeNode(model)
+	if !node.IsBranch() {
+		t.Fatalf("Node with children to load should be a branch")
+	}
+	if node.NumChildren() != 0 {
+		t.Fatalf("Children should not be loaded before expanding")
+	}
+
+	node.Expand()
+	if !node.IsLoading() {
+		t.Fatalf("Node should be loading after the first expand")
+	}
+	if got := childTexts(node); len(got) != 1 || got[0] != loadingText || !node.Objects[0].(*TreeNode).IsPlaceholder() {
+		t.Fatalf("Expected a loading placeholder, got %v", got)
+	}
+
+	model.release <- struct{}{}
+	waitForLoad(t, node)
+	if got := childTexts(node); len(got) != 2 || got[0] != "one" || got[1] != "two" {
+		t.Fatalf("Unexpected children after loading: %v", got)
+	}
+
+	node.Condense()
+	node.Expand()
+	if node.IsLoading() || model.loads != 1 {
+		t.Fatalf("Children should only be loaded once")
+	}
+}
+
+func TestTreeNode_LazyLoadLeaf(t *testing.T) {
+	node := NewTreeNode(newLazyModel())
+	if !node.IsLeaf() {
+		t.Fatalf("Node without children to load should be a leaf")
+	}
+}
+
+func TestTreeNode_LazyLoadRetry(t *testing.T) {
+	model := newLazyModel("one")
+	model.fail = true
+	node := NewTreeNode(model)
+
+	model.release <- struct{}{}
+	node.Expand()
+	waitForLoad(t, node)
+	if node.NumChildren() != 1 || !node.Objects[0].(*TreeNode).IsPlaceholder() {
+		t.Fatalf("Expected an error placeholder after loading failed")
+	}
+
+	model.fail = false
+	model.release <- struct{}{}
+	node.Objects[0].(*TreeNode).DoubleTapped(&fyne.PointEvent{})
+	waitForLoad(t, node)
+	if got := childTexts(node); len(got) != 1 || got[0] != "one" {
+		t.Fatalf("Unexpected children after retrying: %v", got)
+	}
+}
+
+// pendingModel blocks each load until the test sends its children, so loads can be finished in any order.
+type pendingModel struct {
+	StaticNodeModel
+	loads chan chan []string
+}
+
+func (m *pendingModel) HasChildren() bool {
+	return true
+}
+
+func (m *pendingModel) LoadChildren() ([]*TreeNode, error) {
+	result := make(chan []string)
+	m.loads <- result
+	var nodes []*TreeNode
+	for _, text := range <-result {
+		nodes = append(nodes, NewLeafTreeNode(NewStaticModel(nil, text)))
+	}
+	return nodes, nil
+}
+
+func finishStaleLoad(node *TreeNode, load chan []string) {
+	load <- []string{"stale"}
+	time.Sleep(20 * time.Millisecond)
+	node.loadMux.Lock()
+	node.loadMux.Unlock()
+}
+
+func TestTreeNode_LazyLoadStale(t *testing.T) {
+	model := &pendingModel{StaticNodeModel{Text: "pending"}, make(chan chan []string)}
+	node := NewTreeNode(model)
+	node.Expand()
+	stale := <-model.loads
+	node.Condense()
+	if node.IsLoading() {
+		t.Fatalf("Expected condensing to cancel the load")
+	}
+	node.Expand()
+	fresh := <-model.loads
+	fresh <- []string{"fresh"}
+	waitForLoad(t, node)
+	finishStaleLoad(node, stale)
+	if got := childTexts(node); len(got) != 1 || got[0] != "fresh" {
+		t.Fatalf("Expected a cancelled load to be discarded, got %v", got)
+	}
+
+	node.ReloadChildren()
+	stale = <-model.loads
+	node.ReloadChildren()
+	fresh = <-model.loads
+	fresh <- []string{"reloaded"}
+	waitForLoad(t, node)
+	finishStaleLoad(node, stale)
+	if got := childTexts(node); len(got) != 1 || got[0] != "reloaded" {
+		t.Fatalf("Expected a replaced load to be discarded, got %v", got)
+	}
+}
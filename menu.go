@@ -0,0 +1,173 @@
+package fynetree
+
+import (
+	"strings"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+)
+
+// MenuProvider builds the context menu for a node. The selection holds every selected node when the node is selected,
+// or just the node otherwise. Returning nil falls back to the node model's menu.
+type MenuProvider func(node *TreeNode, selection []*TreeNode) *fyne.Menu
+
+// MenuActions is a set of built-in context menu items.
+type MenuActions int
+
+const (
+	// MenuExpandAll expands the selected nodes and all of their descendants.
+	MenuExpandAll MenuActions = 1 << iota
+	// MenuCollapseAll condenses the selected nodes and all of their descendants.
+	MenuCollapseAll
+	// MenuCopyText copies the text of the selected nodes to the clipboard, one per line.
+	MenuCopyText
+	// MenuRename starts renaming the node, if it's the only selected node and its model is an EditableModel.
+	MenuRename
+	// MenuDelete removes the selected nodes from the tree.
+	MenuDelete
+
+	// MenuAllActions includes every built-in item.
+	MenuAllActions = MenuExpandAll | MenuCollapseAll | MenuCopyText | MenuRename | MenuDelete
+)
+
+// ContextMenu builds the menu shown when the node is tapped with the secondary button. The items come from the
+// container's MenuProvider, or the model's GetContextMenu if there's no provider, followed by the container's
+// built-in MenuActions. Nil is returned if there are no items.
+func (t *TreeContainer) ContextMenu(node *TreeNode) *fyne.Menu {
+	if node == nil || node.IsPlaceholder() {
+		return nil
+	}
+	selection := t.menuSelection(node)
+	var menu *fyne.Menu
+	if t.MenuProvider != nil {
+		menu = t.MenuProvider(node, selection)
+	}
+	if menu == nil {
+		if model, ok := node.model.(MenuModel); ok {
+			menu = model.GetContextMenu(selection)
+		}
+	}
+	actions := t.actionItems(node, selection)
+	if len(actions) == 0 {
+		return menu
+	}
+	if menu == nil {
+		return fyne.NewMenu("", actions...)
+	}
+	items := append([]*fyne.MenuItem{}, menu.Items...)
+	if len(items) > 0 {
+		items = append(items, fyne.NewMenuItemSeparator())
+	}
+	return fyne.NewMenu(menu.Label, append(items, actions...)...)
+}
+
+// menuSelection returns the nodes a context menu for the node acts on.
+func (t *TreeContainer) menuSelection(node *TreeNode) []*TreeNode {
+	if t.IsSelected(node) {
+		return t.SelectedNodes()
+	}
+	return []*TreeNode{node}
+}
+
+// actionItems creates the container's built-in menu items for the selection.
+func (t *TreeContainer) actionItems(node *TreeNode, selection []*TreeNode) []*fyne.MenuItem {
+	var items []*fyne.MenuItem
+	if t.MenuActions&MenuExpandAll != 0 {
+		items = append(items, fyne.NewMenuItem("Expand All", func() {
+			t.transaction(func() {
+				for _, selected := range selection {
+					selected.ExpandAll()
+				}
+			})
+		}))
+	}
+	if t.MenuActions&MenuCollapseAll != 0 {
+		items = append(items, fyne.NewMenuItem("Collapse All", func() {
+			t.transaction(func() {
+				for _, selected := range selection {
+					selected.CondenseAll()
+				}
+			})
+		}))
+	}
+	if t.MenuActions&MenuCopyText != 0 {
+		items = append(items, fyne.NewMenuItem("Copy Text", func() {
+			t.copyText(selection)
+		}))
+	}
+	if _, editable := node.model.(EditableModel); t.MenuActions&MenuRename != 0 && editable && len(selection) == 1 {
+		items = append(items, fyne.NewMenuItem("Rename", func() {
+			_ = t.StartRename(node)
+		}))
+	}
+	if t.MenuActions&MenuDelete != 0 {
+		items = append(items, fyne.NewMenuItem("Delete", func() {
+			t.DeleteNodes(selection...)
+		}))
+	}
+	return items
+}
+
+// DeleteNodes removes each node from its parent or from the container. The removals are undone together if the
+// container has a History.
+func (t *TreeContainer) DeleteNodes(nodes ...*TreeNode) {
+	t.transaction(func() {
+		t.Batch(func() {
+			for _, node := range nodes {
+				if node.treeContainer() != t {
+					continue
+				}
+				if _, err := t.siblingList(node).Remove(node); err != nil {
+					fyne.LogError("Unable to delete node", err)
+				}
+			}
+		})
+	})
+}
+
+// transaction calls the function in a History transaction if the container has a History.
+func (t *TreeContainer) transaction(fn func()) {
+	if t.History == nil {
+		fn()
+		return
+	}
+	t.History.Transaction(fn)
+}
+
+// copyText copies the nodes' text to the clipboard of the window showing the container.
+func (t *TreeContainer) copyText(nodes []*TreeNode) {
+	texts := make([]string, len(nodes))
+	for i, node := range nodes {
+		texts[i] = node.GetModelText()
+	}
+	app := fyne.CurrentApp()
+	if app == nil {
+		return
+	}
+	c := app.Driver().CanvasForObject(t)
+	for _, w := range app.Driver().AllWindows() {
+		if w.Canvas() == c {
+			w.Clipboard().SetContent(strings.Join(texts, "\n"))
+			return
+		}
+	}
+}
+
+// showContextMenu selects the node if it isn't already selected, then shows its context menu at the tapped position.
+func (t *TreeContainer) showContextMenu(node *TreeNode, pe *fyne.PointEvent) {
+	menu := t.ContextMenu(node)
+	if menu == nil {
+		return
+	}
+	if !t.IsSelected(node) && t.SelectionMode != SelectionNone {
+		t.setSelection([]*TreeNode{node}, node)
+		// Rebuild the menu so it acts on the new selection.
+		menu = t.ContextMenu(node)
+	}
+	t.setCursor(node)
+	if app := fyne.CurrentApp(); app != nil {
+		if c := app.Driver().CanvasForObject(t); c != nil {
+			widget.ShowPopUpMenuAtPosition(menu, c, pe.AbsolutePosition)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+)
+
+type menuModel struct {
+	StaticNodeModel
+}
+
+func (m *menuModel) GetContextMenu(selection []*TreeNode) *fyne.Menu {
+	return fyne.NewMenu("", fyne.NewMenuItem("Model Item", func() {}))
+}
+
+func menuLabels(menu *fyne.Menu) []string {
+	if menu == nil {
+		return nil
+	}
+	var labels []string
+	for _, item := range menu.Items {
+		if item.IsSeparator {
+			labels = append(labels, "-")
+		} else {
+			labels = append(labels, item.Label)
+		}
+	}
+	return labels
+}
+
+func assertMenu(t *testing.T, menu *fyne.Menu, want ...string) {
+	t.Helper()
+	got := menuLabels(menu)
+	if len(got) != len(want) {
+		t.Fatalf("Expected menu %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected menu %v, got %v", want, got)
+		}
+	}
+}
+
+func menuAction(menu *fyne.Menu, label string) func() {
+	for _, item := range menu.Items {
+		if item.Label == label {
+			return item.Action
+		}
+	}
+	return nil
+}
+
+func TestTreeContainer_ContextMenuSources(t *testing.T) {
+	traversalSetup()
+	if menu := treeContainer.ContextMenu(nodeA); menu != nil {
+		t.Fatalf("Expected no menu by default")
+	}
+
+	node := NewTreeNode(&menuModel{StaticNodeModel{Text: "menu"}})
+	_ = nodeB.Append(node)
+	assertMenu(t, treeContainer.ContextMenu(node), "Model Item")
+
+	var provided []*TreeNode
+	treeContainer.MenuProvider = func(node *TreeNode, selection []*TreeNode) *fyne.Menu {
+		provided = selection
+		if node == nodeA {
+			return fyne.NewMenu("", fyne.NewMenuItem("Provided", func() {}))
+		}
+		return nil
+	}
+	treeContainer.MenuActions = MenuCopyText | MenuDelete
+	assertMenu(t, treeContainer.ContextMenu(nodeA), "Provided", "-", "Copy Text", "Delete")
+	assertMenu(t, treeContainer.ContextMenu(node), "Model Item", "-", "Copy Text", "Delete")
+	assertMenu(t, treeContainer.ContextMenu(nodeB), "Copy Text", "Delete")
+
+	treeContainer.SelectionMode = SelectionMulti
+	treeContainer.Select(nodeA)
+	treeContainer.Select(nodeB)
+	treeContainer.ContextMenu(nodeA)
+	if len(provided) != 2 {
+		t.Fatalf("Expected the menu for a selected node to act on the selection, got %d nodes", len(provided))
+	}
+	treeContainer.ContextMenu(nodeC)
+	if len(provided) != 1 || provided[0] != nodeC {
+		t.Fatalf("Expected the menu for an unselected node to act on just that node")
+	}
+}
+
+func TestTreeContainer_MenuActions(t *testing.T) {
+	traversalSetup()
+	w := showContainer()
+	defer w.Close()
+	treeContainer.History = NewHistory()
+	treeContainer.MenuActions = MenuAllActions
+
+	assertMenu(t, treeContainer.ContextMenu(rootNode), "Expand All", "Collapse All", "Copy Text", "Delete")
+	menuAction(treeContainer.ContextMenu(rootNode), "Expand All")()
+	if !rootNode.IsExpanded() || !nodeA.IsExpanded() || !nodeC.IsExpanded() {
+		t.Fatalf("Expected Expand All to expand every descendant")
+	}
+	menuAction(treeContainer.ContextMenu(nodeA), "Collapse All")()
+	if nodeA.IsExpanded() || nodeC.IsExpanded() || !rootNode.IsExpanded() {
+		t.Fatalf("Expected Collapse All to condense the node and its descendants")
+	}
+
+	treeContainer.SelectionMode = SelectionMulti
+	treeContainer.Select(nodeC)
+	treeContainer.Select(nodeD)
+	menuAction(treeContainer.ContextMenu(nodeC), "Copy Text")()
+	if got := w.Clipboard().Content(); got != "C\nD" {
+		t.Fatalf("Expected selected text to be copied, got '%s'", got)
+	}
+	menuAction(treeContainer.ContextMenu(nodeC), "Delete")()
+	if nodeA.NumChildren() != 0 {
+		t.Fatalf("Expected selected nodes to be deleted")
+	}
+	_ = treeContainer.Undo()
+	if joinedChildTexts(nodeA) != "C,D" {
+		t.Fatalf("Expected delete to be undone in one step, got %s", joinedChildTexts(nodeA))
+	}
+}
+
+func TestTreeNode_TappedSecondaryShowsMenu(t *testing.T) {
+	traversalSetup()
+	w := showContainer()
+	defer w.Close()
+	treeContainer.MenuActions = MenuCopyText
+	treeContainer.Select(nodeA)
+
+	rootNode.TappedSecondary(&fyne.PointEvent{})
+	if !rootNode.IsSelected() || nodeA.IsSelected() {
+		t.Fatalf("Expected secondary tap to select the node")
+	}
+	if overlay := w.Canvas().Overlays().Top(); overlay == nil {
+		t.Fatalf("Expected the context menu to be shown")
+	}
+}
@@ -1,6 +1,8 @@
 package fynetree
 
 import (
+	"sync"
+
 	"fyne.io/fyne"
 )
 
@@ -16,6 +18,114 @@ type TreeNodeModel interface {
 	SetTreeNode(node *TreeNode)
 }
 
+// ChildrenProvider is an optional interface a TreeNodeModel can implement to have its children loaded lazily.
+type ChildrenProvider interface {
+	// HasChildren should return whether the node has children to load. Nodes without children are shown as leaves.
+	HasChildren() bool
+
+	// LoadChildren is called in a new goroutine the first time the node is expanded, and should return the node's children.
+	// An error is shown in place of the children, and loading can be retried by activating it or calling ReloadChildren.
+	LoadChildren() ([]*TreeNode, error)
+}
+
+// EditableModel is an optional interface a TreeNodeModel can implement to allow its node to be renamed in place.
+type EditableModel interface {
+	// SetText is called with the new text when a rename is committed. Returning an error rejects the new text.
+	SetText(text string) error
+}
+
+// ColumnModel is an optional interface a TreeNodeModel can implement to provide values for the extra columns of a
+// TreeTable.
+type ColumnModel interface {
+	// GetColumnText should return the text to display in the given column. Column 0 is the tree column, which shows GetText.
+	GetColumnText(column int) string
+}
+
+// KeyedModel is an optional interface a TreeNodeModel can implement to give its node a stable key, which is used to
+// match nodes when expansion state is restored to a rebuilt tree.
+type KeyedModel interface {
+	// GetKey should return a key that identifies the node, and is unique among all nodes in the tree.
+	GetKey() string
+}
+
+// TooltipModel is an optional interface a TreeNodeModel can implement to show a tooltip when its row is hovered.
+type TooltipModel interface {
+	// GetTooltip should return the tooltip's text, or "" if there's no tooltip.
+	GetTooltip() string
+}
+
+// TooltipContentModel is an optional interface a TreeNodeModel can implement to show custom content in its tooltip.
+// It takes precedence over TooltipModel.
+type TooltipContentModel interface {
+	// GetTooltipContent should return the content to show in the tooltip, or nil if there's no tooltip.
+	GetTooltipContent() fyne.CanvasObject
+}
+
+// MenuModel is an optional interface a TreeNodeModel can implement to provide its node's context menu.
+type MenuModel interface {
+	// GetContextMenu should return the menu to show for the node, or nil for no menu. The selection holds every selected
+	// node when the node is selected, or just the node otherwise.
+	GetContextMenu(selection []*TreeNode) *fyne.Menu
+}
+
+// ModelListener receives change notifications from an ObservableModel.
+type ModelListener interface {
+	// ModelChanged is called after the model's icon or text has changed.
+	ModelChanged()
+}
+
+// ObservableModel is an optional interface a TreeNodeModel can implement to have its node refresh automatically when
+// its icon or text changes. A TreeNode adds itself as a listener when it's initialized, removes itself when it's
+// removed from its parent or container, and adds itself again if it's added back.
+type ObservableModel interface {
+	AddListener(listener ModelListener)
+	RemoveListener(listener ModelListener)
+}
+
+var _ ObservableModel = (*ModelNotifier)(nil)
+
+// ModelNotifier can be embedded in a TreeNodeModel to implement ObservableModel.
+// Call NotifyChanged after changing a value returned by GetIconResource or GetText.
+type ModelNotifier struct {
+	mux       sync.Mutex
+	listeners []ModelListener
+}
+
+// AddListener registers the listener to be notified of changes. Adding the same listener twice has no effect.
+func (m *ModelNotifier) AddListener(listener ModelListener) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for _, l := range m.listeners {
+		if l == listener {
+			return
+		}
+	}
+	m.listeners = append(m.listeners, listener)
+}
+
+// RemoveListener stops notifying the listener of changes.
+func (m *ModelNotifier) RemoveListener(listener ModelListener) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for i, l := range m.listeners {
+		if l == listener {
+			m.listeners = append(m.listeners[:i], m.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// NotifyChanged tells every registered listener that the model has changed.
+func (m *ModelNotifier) NotifyChanged() {
+	m.mux.Lock()
+	listeners := make([]ModelListener, len(m.listeners))
+	copy(listeners, m.listeners)
+	m.mux.Unlock()
+	for _, l := range listeners {
+		l.ModelChanged()
+	}
+}
+
 var _ TreeNodeModel = (*StaticNodeModel)(nil)
 
 type StaticNodeModel struct {
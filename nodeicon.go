@@ -2,6 +2,7 @@ package fynetree
 
 import (
 	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
 	"fyne.io/fyne/widget"
 )
 
@@ -27,6 +28,7 @@ func (icon *nodeIcon) Tapped(pe *fyne.PointEvent) {
 	if onTapped := icon.node.OnIconTapped; onTapped != nil {
 		onTapped(pe)
 	}
+	icon.node.tapped(pe)
 }
 
 func (icon *nodeIcon) TappedSecondary(pe *fyne.PointEvent) {
@@ -36,3 +38,19 @@ func (icon *nodeIcon) TappedSecondary(pe *fyne.PointEvent) {
 func (icon *nodeIcon) DoubleTapped(pe *fyne.PointEvent) {
 	icon.node.DoubleTapped(pe)
 }
+
+// MouseDown records held modifier keys so the following tap can extend the selection.
+func (icon *nodeIcon) MouseDown(me *desktop.MouseEvent) {
+	icon.node.tapModifier = me.Modifier
+}
+
+func (icon *nodeIcon) MouseUp(_ *desktop.MouseEvent) {
+}
+
+func (icon *nodeIcon) Dragged(ev *fyne.DragEvent) {
+	icon.node.Dragged(ev)
+}
+
+func (icon *nodeIcon) DragEnd() {
+	icon.node.DragEnd()
+}
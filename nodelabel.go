@@ -2,6 +2,7 @@ package fynetree
 
 import (
 	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
 	"fyne.io/fyne/widget"
 )
 
@@ -23,10 +24,22 @@ func newNodeLabel(node *TreeNode, text string) *nodeLabel {
 	return label
 }
 
+// Tapped calls the OnLabelTapped hook and selects the node. Tapping the label of the only selected node again starts
+// renaming it, if its container allows inline renaming.
 func (label *nodeLabel) Tapped(pe *fyne.PointEvent) {
-	if onTapped := label.node.OnLabelTapped; onTapped != nil {
+	node := label.node
+	var wasSelected bool
+	if c := node.treeContainer(); c != nil {
+		selected := c.SelectedNodes()
+		wasSelected = len(selected) == 1 && selected[0] == node && node.tapModifier == 0
+	}
+	if onTapped := node.OnLabelTapped; onTapped != nil {
 		onTapped(pe)
 	}
+	node.tapped(pe)
+	if c := node.treeContainer(); c != nil {
+		c.renameOnTap(node, wasSelected)
+	}
 }
 
 func (label *nodeLabel) TappedSecondary(pe *fyne.PointEvent) {
@@ -36,3 +49,19 @@ func (label *nodeLabel) TappedSecondary(pe *fyne.PointEvent) {
 func (label *nodeLabel) DoubleTapped(pe *fyne.PointEvent) {
 	label.node.DoubleTapped(pe)
 }
+
+// MouseDown records held modifier keys so the following tap can extend the selection.
+func (label *nodeLabel) MouseDown(me *desktop.MouseEvent) {
+	label.node.tapModifier = me.Modifier
+}
+
+func (label *nodeLabel) MouseUp(_ *desktop.MouseEvent) {
+}
+
+func (label *nodeLabel) Dragged(ev *fyne.DragEvent) {
+	label.node.Dragged(ev)
+}
+
+func (label *nodeLabel) DragEnd() {
+	label.node.DragEnd()
+}
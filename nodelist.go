@@ -3,7 +3,6 @@ package fynetree
 import (
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 
 	"fyne.io/fyne"
@@ -13,8 +12,11 @@ type nodeList struct {
 	OnAfterAddition func(item fyne.CanvasObject)
 	OnAfterRemoval  func(item fyne.CanvasObject)
 
-	mux     sync.Mutex
-	Objects []fyne.CanvasObject
+	mux        sync.Mutex
+	comparator NodeComparator
+	autoSort   bool
+	history    func() *History
+	Objects    []fyne.CanvasObject
 }
 
 func (n *nodeList) Len() int {
@@ -22,13 +24,29 @@ func (n *nodeList) Len() int {
 }
 
 // InsertAt a new TreeNode at the given position as a child of this Objects.
+// An error is returned if the list is auto-sorted, since the node would be moved to its sorted position anyway.
 func (n *nodeList) InsertAt(position int, node *TreeNode) error {
+	if n.IsAutoSorted() {
+		return errors.New("unable to insert at a position in an auto-sorted list")
+	}
+	return n.insertAt(position, node)
+}
+
+// insertNear inserts the node at the given position, or in sorted order if the list is auto-sorted.
+func (n *nodeList) insertNear(position int, node *TreeNode) error {
+	if n.IsAutoSorted() {
+		return n.InsertSorted(node)
+	}
+	return n.insertAt(position, node)
+}
+
+func (n *nodeList) insertAt(position int, node *TreeNode) error {
 	n.mux.Lock()
 	if node != nil {
 		childrenLen := n.Len()
 		if position == childrenLen {
 			n.mux.Unlock()
-			return n.Append(node)
+			return n.appendNode(node)
 		} else if position == 0 {
 			node.Show()
 			n.Objects = append([]fyne.CanvasObject{node}, n.Objects...)
@@ -45,36 +63,59 @@ func (n *nodeList) InsertAt(position int, node *TreeNode) error {
 		if n.OnAfterAddition != nil {
 			n.OnAfterAddition(node)
 		}
+		n.recordChange(node, position, true)
 		return nil
 	}
 	n.mux.Unlock()
 	return errors.New("unable to insert nil node")
 }
 
+// InsertSorted inserts the node before the first node that doesn't sort before it with the list's comparator.
 func (n *nodeList) InsertSorted(node *TreeNode) error {
+	return n.InsertSortedFunc(node, n.getComparator())
+}
+
+// InsertSortedFunc inserts the node before the first node that doesn't sort before it with the given comparator. The
+// list's own comparator is unchanged.
+func (n *nodeList) InsertSortedFunc(node *TreeNode, compare NodeComparator) error {
+	if node == nil {
+		return errors.New("unable to insert nil node")
+	}
+	if compare == nil {
+		compare = n.getComparator()
+	}
 	n.mux.Lock()
 	nodes := n.Objects
 	for i, c := range nodes {
 		if treeNode, ok := c.(*TreeNode); ok {
-			if strings.ToUpper(node.GetModelText()) <= strings.ToUpper(treeNode.GetModelText()) {
+			if compare(node, treeNode) <= 0 {
 				n.mux.Unlock()
-				return n.InsertAt(i, node)
+				return n.insertAt(i, node)
 			}
 		}
 	}
 	n.mux.Unlock()
-	return n.Append(node)
+	return n.appendNode(node)
 }
 
-// Append adds a node to the end of the Objects.
+// Append adds a node to the end of the Objects, or in sorted order if the list is auto-sorted.
 func (n *nodeList) Append(node *TreeNode) error {
+	if n.IsAutoSorted() {
+		return n.InsertSorted(node)
+	}
+	return n.appendNode(node)
+}
+
+func (n *nodeList) appendNode(node *TreeNode) error {
 	if node != nil {
 		n.mux.Lock()
 		n.Objects = append(n.Objects, node)
+		position := len(n.Objects) - 1
 		n.mux.Unlock()
 		if n.OnAfterAddition != nil {
 			n.OnAfterAddition(node)
 		}
+		n.recordChange(node, position, true)
 		return nil
 	}
 	return errors.New("unable to append nil node")
@@ -85,6 +126,7 @@ func (n *nodeList) RemoveAt(position int) (removedNode fyne.CanvasObject, err er
 	n.mux.Lock()
 	removedNode, err = n.removeAtImpl(position)
 	n.mux.Unlock()
+	n.afterRemoval(removedNode, position, err)
 	return
 }
 
@@ -101,12 +143,21 @@ func (n *nodeList) removeAtImpl(position int) (removedNode fyne.CanvasObject, er
 		n.Objects = n.Objects[:position]
 	} else {
 		err = fmt.Errorf("position %d is out of bounds for %d length children", position, childrenLen)
+	}
+	return
+}
+
+// afterRemoval calls the removal hook once the list is unlocked, so the hook is free to read the list.
+func (n *nodeList) afterRemoval(removedNode fyne.CanvasObject, position int, err error) {
+	if err != nil {
 		return
 	}
 	if n.OnAfterRemoval != nil {
 		n.OnAfterRemoval(removedNode)
 	}
-	return
+	if node, ok := removedNode.(*TreeNode); ok {
+		n.recordChange(node, position, false)
+	}
 }
 
 // Remove searches for the given node to remove and return it if it exists, returns nil and an error otherwise.
@@ -117,6 +168,7 @@ func (n *nodeList) Remove(node *TreeNode) (removedNode fyne.CanvasObject, err er
 			if existing == node {
 				removedNode, err := n.removeAtImpl(i)
 				n.mux.Unlock()
+				n.afterRemoval(removedNode, i, err)
 				return removedNode, err
 			}
 		}
@@ -0,0 +1,56 @@
+package fynetree
+
+var _ ModelListener = (*TreeNode)(nil)
+
+// ModelChanged is called by an ObservableModel when its icon or text changes, and refreshes the node's view.
+// The node is moved to keep its siblings in order if its parent is auto-sorted.
+func (n *TreeNode) ModelChanged() {
+	n.resortInParent()
+	n.Refresh()
+}
+
+// observeModel subscribes to the node's model if it's observable and not already subscribed.
+func (n *TreeNode) observeModel() {
+	observable, ok := n.model.(ObservableModel)
+	if !ok {
+		return
+	}
+	n.mux.Lock()
+	if n.observing {
+		n.mux.Unlock()
+		return
+	}
+	n.observing = true
+	n.mux.Unlock()
+	observable.AddListener(n)
+}
+
+// unobserveModel unsubscribes from the node's model if it's subscribed.
+func (n *TreeNode) unobserveModel() {
+	observable, ok := n.model.(ObservableModel)
+	if !ok {
+		return
+	}
+	n.mux.Lock()
+	if !n.observing {
+		n.mux.Unlock()
+		return
+	}
+	n.observing = false
+	n.mux.Unlock()
+	observable.RemoveListener(n)
+}
+
+// setObserving subscribes or unsubscribes this node and its descendants, as a subtree is added to or removed from the tree.
+func (n *TreeNode) setObserving(observing bool) {
+	if observing {
+		n.observeModel()
+	} else {
+		n.unobserveModel()
+	}
+	for _, obj := range n.nodeList.Objects {
+		if child, ok := obj.(*TreeNode); ok {
+			child.setObserving(observing)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+)
+
+type observableModel struct {
+	ModelNotifier
+	StaticNodeModel
+}
+
+func newObservableModel(text string) *observableModel {
+	return &observableModel{
+		StaticNodeModel: StaticNodeModel{Text: text},
+	}
+}
+
+func (o *observableModel) setText(text string) {
+	o.Text = text
+	o.NotifyChanged()
+}
+
+func (o *observableModel) numListeners() int {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	return len(o.listeners)
+}
+
+func TestModelNotifier_AddRemove(t *testing.T) {
+	treeNodeSetup()
+	notifier := &ModelNotifier{}
+	notifier.AddListener(nodeA)
+	notifier.AddListener(nodeA)
+	notifier.AddListener(nodeB)
+	if len(notifier.listeners) != 2 {
+		t.Fatalf("Expected 2 listeners, got %d", len(notifier.listeners))
+	}
+	notifier.RemoveListener(nodeA)
+	if len(notifier.listeners) != 1 || notifier.listeners[0] != nodeB {
+		t.Fatalf("Expected only node B to be listening")
+	}
+}
+
+func TestTreeNode_ObservesModel(t *testing.T) {
+	test.NewApp()
+	model := newObservableModel("Before")
+	node := NewTreeNode(model)
+	if model.numListeners() != 1 {
+		t.Fatalf("Expected node to subscribe on init, got %d listeners", model.numListeners())
+	}
+
+	w := test.NewWindow(node)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 100))
+	model.setText("After")
+	label := test.WidgetRenderer(node).(*treeEntryRenderer).row
+	if text := test.WidgetRenderer(label).(*treeRowRenderer).label.Text; text != "After" {
+		t.Fatalf("Expected label to be updated to 'After', got '%s'", text)
+	}
+}
+
+func TestTreeNode_ObservesModelInContainer(t *testing.T) {
+	test.NewApp()
+	containerSetup()
+	model := newObservableModel("Before")
+	node := NewTreeNode(model)
+	_ = nodeA.Append(node)
+	nodeA.Expand()
+	_ = treeContainer.Append(nodeA)
+
+	w := test.NewWindow(treeContainer)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 200))
+	model.setText("After")
+	for _, row := range treeContainer.viewport.renderer.rows {
+		if row.node == node {
+			if text := test.WidgetRenderer(row).(*treeRowRenderer).label.Text; text != "After" {
+				t.Fatalf("Expected label to be updated to 'After', got '%s'", text)
+			}
+			return
+		}
+	}
+	t.Fatalf("Expected a row to be bound to the observed node")
+}
+
+func TestTreeNode_UnobservesModelOnRemoval(t *testing.T) {
+	treeNodeSetup()
+	model := newObservableModel("Child")
+	node := NewTreeNode(model)
+	_ = nodeA.Append(node)
+	_ = rootNode.Append(nodeA)
+
+	if _, err := rootNode.Remove(nodeA); err != nil {
+		t.Fatalf("Failed to remove node: %v", err)
+	}
+	if model.numListeners() != 0 {
+		t.Fatalf("Expected descendant to unsubscribe on removal, got %d listeners", model.numListeners())
+	}
+
+	_ = rootNode.Append(nodeA)
+	if model.numListeners() != 1 {
+		t.Fatalf("Expected descendant to subscribe again when added back, got %d listeners", model.numListeners())
+	}
+}
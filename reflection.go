@@ -0,0 +1,434 @@
+package fynetree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+)
+
+var _ TreeNodeModel = (*ValueModel)(nil)
+var _ KeyedModel = (*ValueModel)(nil)
+
+// ValueTag is the struct tag that sets the name a field is shown with. A field tagged `tree:"-"` is hidden.
+const ValueTag = "tree"
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// ValueModel shows a Go value found by reflection. Structs list their fields, maps their entries sorted by key, and
+// arrays and slices their elements, the first time they're expanded. Pointers and interfaces show the value they refer
+// to, and a value that refers back to one of its ancestors is shown as a leaf instead of being listed again.
+type ValueModel struct {
+	parent *ValueModel
+	name   string
+	access func(parent reflect.Value) reflect.Value
+	live   bool
+	value  reflect.Value
+	ref    valueRef
+	cycle  *ValueModel
+
+	mux    sync.Mutex
+	loaded bool
+	node   *TreeNode
+}
+
+// valueRef identifies the memory a pointer, map or slice refers to, so cycles can be found.
+type valueRef struct {
+	ptr    uintptr
+	typ    reflect.Type
+	length int
+}
+
+// NewValueNode creates a node for a snapshot of the value, labelled with the name. Later changes to the value aren't
+// shown, except through pointers that haven't been listed yet.
+func NewValueNode(name string, value interface{}) *TreeNode {
+	return NewTreeNode(newValueModel(nil, name, reflect.ValueOf(value), nil, false))
+}
+
+// NewLiveValueNode creates a node for the value that reads it again each time the node is refreshed, so the tree
+// shows its current state. The value should be a pointer for changes made through other references to be seen.
+// Fields and elements listed before the value changed keep their place, and are shown as nil if they're gone, until
+// the model's Refresh is called to add and remove entries.
+func NewLiveValueNode(name string, value interface{}) *TreeNode {
+	return NewTreeNode(newValueModel(nil, name, reflect.ValueOf(value), nil, true))
+}
+
+func newValueModel(parent *ValueModel, name string, value reflect.Value, access func(reflect.Value) reflect.Value,
+	live bool) *ValueModel {
+	m := &ValueModel{parent: parent, name: name, access: access, live: live, value: value}
+	var ok bool
+	if m.ref, ok = refOf(value); ok {
+		for ancestor := parent; ancestor != nil; ancestor = ancestor.parent {
+			if ancestor.ref == m.ref {
+				m.cycle = ancestor
+				break
+			}
+		}
+	}
+	return m
+}
+
+// Name returns the name the value is shown with.
+func (m *ValueModel) Name() string {
+	return m.name
+}
+
+// Value returns the value, read again from its root if the node is live. An invalid value is returned if a live
+// value is gone.
+func (m *ValueModel) Value() reflect.Value {
+	if !m.live || m.access == nil {
+		return m.value
+	}
+	return m.access(indirect(m.parent.Value()))
+}
+
+// Kind returns the type of the value, using the document kinds for scalars, structs and maps as objects, and arrays
+// and slices as arrays.
+func (m *ValueModel) Kind() DocumentKind {
+	return valueKind(indirect(m.Value()))
+}
+
+// IsCycle returns whether the value refers back to one of its ancestors.
+func (m *ValueModel) IsCycle() bool {
+	return m.cycle != nil
+}
+
+// GetIconResource picks an icon from the theme for the type of the value.
+func (m *ValueModel) GetIconResource() fyne.Resource {
+	if m.cycle != nil {
+		return theme.ViewRefreshIcon()
+	}
+	v := indirect(m.Value())
+	node := m.treeNode()
+	return kindIcon(valueKind(v), v.Kind() == reflect.Bool && v.Bool(), node != nil && node.IsExpanded())
+}
+
+// GetText returns "name: value" for scalars, and the name and type for other values, with the number of entries in
+// maps, arrays and slices.
+func (m *ValueModel) GetText() string {
+	if m.cycle != nil {
+		return fmt.Sprintf("%s: cycle to %s", m.name, m.cycle.name)
+	}
+	value := m.Value()
+	v := indirect(value)
+	switch valueKind(v) {
+	case DocumentObject:
+		if v.Kind() == reflect.Map {
+			return fmt.Sprintf("%s %s{%d}", m.name, typeOf(value), v.Len())
+		}
+		return fmt.Sprintf("%s %s", m.name, typeOf(value))
+	case DocumentArray:
+		return fmt.Sprintf("%s %s[%d]", m.name, typeOf(value), v.Len())
+	}
+	return m.name + ": " + valueText(v)
+}
+
+// GetKey returns the path of names from the root, so expansion state can be restored after the tree is rebuilt.
+func (m *ValueModel) GetKey() string {
+	name := strings.NewReplacer("~", "~0", "/", "~1").Replace(m.name)
+	if m.parent == nil {
+		return name
+	}
+	return m.parent.GetKey() + "/" + name
+}
+
+// SetTreeNode shows scalars, empty values and cycles as leaves, and lists entries before the node is first expanded.
+func (m *ValueModel) SetTreeNode(node *TreeNode) {
+	m.mux.Lock()
+	m.node = node
+	m.mux.Unlock()
+	if m.cycle != nil || !hasEntries(indirect(m.Value())) {
+		node.SetLeaf()
+	}
+	if m.cycle != nil {
+		return
+	}
+	node.OnBeforeExpand = func() {
+		m.mux.Lock()
+		loaded := m.loaded
+		m.loaded = true
+		m.mux.Unlock()
+		if !loaded {
+			for _, child := range m.children() {
+				if err := node.Append(NewTreeNode(child)); err != nil {
+					fyne.LogError("Unable to add value entry", err)
+				}
+			}
+		}
+	}
+}
+
+// Refresh redraws the node. A live value is read again first, so values that gained or lost entries become branches or
+// leaves, and listed fields, entries and elements that are gone are removed and new ones are added in order. Entries
+// that are still there keep their nodes, so they stay expanded and selected.
+func (m *ValueModel) Refresh() {
+	node := m.treeNode()
+	if node == nil {
+		return
+	}
+	if !m.live {
+		node.Refresh()
+		return
+	}
+	node.batch(func() {
+		m.reconcile(node)
+		node.Refresh()
+	})
+}
+
+// reconcile updates the node and its listed children to match the current value.
+func (m *ValueModel) reconcile(node *TreeNode) {
+	if m.cycle != nil {
+		return
+	}
+	if !hasEntries(indirect(m.Value())) {
+		m.mux.Lock()
+		m.loaded = false
+		m.mux.Unlock()
+		node.removeAllChildren()
+		if node.IsBranch() {
+			node.SetLeaf()
+		}
+		return
+	}
+	if node.IsLeaf() {
+		node.SetBranch()
+	}
+	m.mux.Lock()
+	loaded := m.loaded
+	m.mux.Unlock()
+	if !loaded {
+		return
+	}
+
+	entries := m.children()
+	listed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		listed[entry.name] = true
+	}
+	existing := make(map[string]*TreeNode)
+	for _, child := range node.Children() {
+		model, ok := child.model.(*ValueModel)
+		if ok && listed[model.name] && model.Value().IsValid() {
+			existing[model.name] = child
+			continue
+		}
+		if _, err := node.Remove(child); err != nil {
+			fyne.LogError("Unable to remove value entry", err)
+		}
+	}
+	for i, entry := range entries {
+		if child, ok := existing[entry.name]; ok {
+			child.model.(*ValueModel).reconcile(child)
+			continue
+		}
+		if err := node.insertNear(i, NewTreeNode(entry)); err != nil {
+			fyne.LogError("Unable to add value entry", err)
+		}
+	}
+}
+
+func (m *ValueModel) ownsChildren() bool {
+	return true
+}
+
+func (m *ValueModel) treeNode() *TreeNode {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.node
+}
+
+// children creates models for the fields of a struct, the entries of a map, or the elements of an array or slice.
+func (m *ValueModel) children() []*ValueModel {
+	v := indirect(m.Value())
+	var children []*ValueModel
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, shown := fieldName(t.Field(i))
+			if !shown {
+				continue
+			}
+			index := i
+			access := func(parent reflect.Value) reflect.Value {
+				if !parent.IsValid() || parent.Type() != t {
+					return reflect.Value{}
+				}
+				return parent.Field(index)
+			}
+			children = append(children, newValueModel(m, name, v.Field(i), access, m.live))
+		}
+	case reflect.Map:
+		type entry struct {
+			key  reflect.Value
+			name string
+		}
+		var entries []entry
+		for _, key := range v.MapKeys() {
+			entries = append(entries, entry{key: key, name: keyText(key)})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].name < entries[j].name
+		})
+		t := v.Type()
+		for _, e := range entries {
+			key := e.key
+			access := func(parent reflect.Value) reflect.Value {
+				if !parent.IsValid() || parent.Type() != t {
+					return reflect.Value{}
+				}
+				return parent.MapIndex(key)
+			}
+			children = append(children, newValueModel(m, "["+e.name+"]", v.MapIndex(key), access, m.live))
+		}
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			index := i
+			access := func(parent reflect.Value) reflect.Value {
+				if !parent.IsValid() || parent.Kind() != reflect.Array && parent.Kind() != reflect.Slice ||
+					index >= parent.Len() {
+					return reflect.Value{}
+				}
+				return parent.Index(index)
+			}
+			children = append(children, newValueModel(m, fmt.Sprintf("[%d]", i), v.Index(i), access, m.live))
+		}
+	}
+	return children
+}
+
+// fieldName returns the name a struct field is shown with, and false if it's hidden by its tag.
+func fieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get(ValueTag)
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+// hasEntries returns whether an indirected value has fields, entries or elements to list.
+func hasEntries(v reflect.Value) bool {
+	switch valueKind(v) {
+	case DocumentObject:
+		if v.Kind() == reflect.Struct {
+			return v.NumField() > 0
+		}
+		return v.Len() > 0
+	case DocumentArray:
+		return v.Len() > 0
+	}
+	return false
+}
+
+// typeOf returns the type of the value, or of the value held by an interface.
+func typeOf(v reflect.Value) reflect.Type {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v.Type()
+}
+
+// indirect follows pointers and interfaces to the value they refer to, stopping at nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// refOf identifies the memory the value refers to. False is returned if it doesn't refer to any.
+func refOf(v reflect.Value) (valueRef, bool) {
+	var ref valueRef
+	found := false
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		if v.Kind() == reflect.Ptr {
+			ref, found = valueRef{ptr: v.Pointer(), typ: v.Type()}, true
+		}
+		v = v.Elem()
+	}
+	if v.IsValid() && (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && !v.IsNil() && v.Len() > 0 {
+		ref, found = valueRef{ptr: v.Pointer(), typ: v.Type(), length: v.Len()}, true
+	}
+	return ref, found
+}
+
+// valueKind classifies an indirected value. Structs that implement fmt.Stringer are shown as strings.
+func valueKind(v reflect.Value) DocumentKind {
+	if !v.IsValid() {
+		return DocumentNull
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return DocumentNull
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return DocumentBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return DocumentNumber
+	case reflect.Struct:
+		if v.CanInterface() && v.Type().Implements(stringerType) {
+			return DocumentString
+		}
+		return DocumentObject
+	case reflect.Map:
+		return DocumentObject
+	case reflect.Array, reflect.Slice:
+		return DocumentArray
+	}
+	return DocumentString
+}
+
+// valueText formats a scalar, including those read from unexported fields.
+func valueText(v reflect.Value) string {
+	if valueKind(v) == DocumentNull {
+		return "nil"
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Complex64, reflect.Complex128:
+		return fmt.Sprint(v.Complex())
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Struct:
+		if v.CanInterface() && v.Type().Implements(stringerType) {
+			return v.Interface().(fmt.Stringer).String()
+		}
+	}
+	return v.Type().String()
+}
+
+// keyText formats a map key. Strings aren't quoted, and structs and arrays are formatted with their fields or elements
+// so each key is labelled differently.
+func keyText(key reflect.Value) string {
+	v := indirect(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Struct, reflect.Array:
+		if valueKind(v) != DocumentString && v.CanInterface() {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+	}
+	return valueText(v)
+}
@@ -0,0 +1,171 @@
+package fynetree
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/theme"
+)
+
+type valueNode struct {
+	Label    string `tree:"label"`
+	Secret   string `tree:"-"`
+	Done     bool
+	count    int
+	Weights  map[string]float64
+	Children []*valueNode
+	Parent   *valueNode
+	Created  time.Time
+	Extra    interface{}
+}
+
+func TestValueModel_Fields(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	value := &valueNode{
+		Label:   "root",
+		Secret:  "hidden",
+		Done:    true,
+		count:   3,
+		Weights: map[string]float64{"b": 0.5, "a": 2},
+		Created: created,
+		Extra:   []int{1, 2},
+	}
+	root := NewValueNode("value", value)
+	if root.GetModelText() != "value *fynetree.valueNode" || root.NumChildren() != 0 {
+		t.Fatalf("Expected an unlisted struct labelled with its type, got '%s'", root.GetModelText())
+	}
+	root.Expand()
+	want := `label: "root",Done: true,count: 3,Weights map[string]float64{2},Children: nil,Parent: nil,` +
+		"Created: " + created.String() + ",Extra []int[2]"
+	if got := joinedChildTexts(root); got != want {
+		t.Fatalf("Expected tagged and unexported fields, got %s", got)
+	}
+	done := root.Children()[1]
+	if done.GetModelIconResource() != theme.CheckButtonCheckedIcon() || !done.IsLeaf() {
+		t.Fatalf("Expected a checked leaf for a true bool")
+	}
+	if !root.Children()[4].IsLeaf() {
+		t.Fatalf("Expected nil slices to be leaves")
+	}
+
+	weights := root.Children()[3]
+	weights.Expand()
+	if got := joinedChildTexts(weights); got != "[a]: 2,[b]: 0.5" {
+		t.Fatalf("Expected map entries sorted by key, got %s", got)
+	}
+	if key := weights.Children()[1].Key(); key != "value/Weights/[b]" {
+		t.Fatalf("Expected key to be the path of names, got '%s'", key)
+	}
+}
+
+func TestValueModel_Cycles(t *testing.T) {
+	parent := &valueNode{Label: "parent"}
+	child := &valueNode{Label: "child", Parent: parent}
+	parent.Children = []*valueNode{child}
+	root := NewValueNode("parent", parent)
+	root.Expand()
+
+	children := root.Children()[4]
+	children.Expand()
+	first := children.Children()[0]
+	first.Expand()
+	back := first.Children()[5]
+	if back.GetModelText() != "Parent: cycle to parent" || !back.IsLeaf() {
+		t.Fatalf("Expected a cycle back to the root, got '%s'", back.GetModelText())
+	}
+	if !back.model.(*ValueModel).IsCycle() || back.GetModelIconResource() != theme.ViewRefreshIcon() {
+		t.Fatalf("Expected a cycle icon")
+	}
+
+	list := []interface{}{nil}
+	list[0] = list
+	self := NewValueNode("list", list)
+	self.Expand()
+	if got := joinedChildTexts(self); got != "[0]: cycle to list" {
+		t.Fatalf("Expected a slice containing itself to be a cycle, got %s", got)
+	}
+}
+
+func TestValueModel_Live(t *testing.T) {
+	value := &valueNode{Label: "before", Weights: map[string]float64{"a": 1}, Children: []*valueNode{{}}}
+	snapshot := NewValueNode("value", *value)
+	live := NewLiveValueNode("value", value)
+	snapshot.Expand()
+	live.Expand()
+	live.Children()[3].Expand()
+	live.Children()[4].Expand()
+
+	value.Label = "after"
+	value.Weights = map[string]float64{"b": 2}
+	value.Children = nil
+	if got := snapshot.Children()[0].GetModelText(); got != `label: "before"` {
+		t.Fatalf("Expected a snapshot to keep its value, got '%s'", got)
+	}
+	if got := live.Children()[0].GetModelText(); got != `label: "after"` {
+		t.Fatalf("Expected a live value to be read again, got '%s'", got)
+	}
+	if got := joinedChildTexts(live.Children()[3]); got != "[a]: nil" {
+		t.Fatalf("Expected a removed map entry to be nil, got %s", got)
+	}
+	if got := joinedChildTexts(live.Children()[4]); got != "[0]: nil" {
+		t.Fatalf("Expected a removed element to be nil, got %s", got)
+	}
+}
+
+func TestValueModel_LiveRefresh(t *testing.T) {
+	value := &valueNode{Weights: map[string]float64{"b": 1}}
+	live := NewLiveValueNode("value", value)
+	live.Expand()
+	weights, children := live.Children()[3], live.Children()[4]
+	weights.Expand()
+	if !children.IsLeaf() {
+		t.Fatalf("Expected a nil slice to be a leaf")
+	}
+
+	value.Weights["a"] = 2
+	value.Weights["c"] = 3
+	value.Children = []*valueNode{{Label: "child"}}
+	live.model.(*ValueModel).Refresh()
+	if live.Children()[3] != weights || !weights.IsExpanded() {
+		t.Fatalf("Expected the map to keep its expanded node")
+	}
+	if got := joinedChildTexts(weights); got != "[a]: 2,[b]: 1,[c]: 3" {
+		t.Fatalf("Expected new keys to be added in order, got %s", got)
+	}
+	if !children.IsBranch() {
+		t.Fatalf("Expected a slice with elements to become a branch")
+	}
+	children.Expand()
+	if got := joinedChildTexts(children); got != "[0] *fynetree.valueNode" {
+		t.Fatalf("Expected the new element to be listed, got %s", got)
+	}
+
+	delete(value.Weights, "b")
+	value.Children = nil
+	live.model.(*ValueModel).Refresh()
+	if got := joinedChildTexts(weights); got != "[a]: 2,[c]: 3" {
+		t.Fatalf("Expected the removed key to be gone, got %s", got)
+	}
+	if !children.IsLeaf() || children.NumChildren() != 0 {
+		t.Fatalf("Expected an emptied slice to become a leaf without children")
+	}
+}
+
+func TestValueModel_CompositeKeys(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	structs := NewValueNode("structs", map[point]string{{1, 2}: "a", {3, 4}: "b"})
+	structs.Expand()
+	if got := joinedChildTexts(structs); got != `[{1 2}]: "a",[{3 4}]: "b"` {
+		t.Fatalf("Expected struct keys to be formatted with their fields, got %s", got)
+	}
+	arrays := NewValueNode("arrays", map[[2]int]int{{1, 2}: 3, {4, 5}: 6})
+	arrays.Expand()
+	if got := joinedChildTexts(arrays); got != "[[1 2]]: 3,[[4 5]]: 6" {
+		t.Fatalf("Expected array keys to be formatted with their elements, got %s", got)
+	}
+	if a, b := arrays.Children()[0].Key(), arrays.Children()[1].Key(); a == b {
+		t.Fatalf("Expected array keys to be distinct, got '%s' twice", a)
+	}
+}
@@ -0,0 +1,178 @@
+package fynetree
+
+import (
+	"errors"
+	"time"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+)
+
+// RenameHandler is called after a node has been renamed in place.
+type RenameHandler func(node *TreeNode, oldText, newText string)
+
+// RenameErrorHandler is called when a node's model rejects a new name.
+type RenameErrorHandler func(node *TreeNode, err error)
+
+// StartRename replaces the node's label with an entry so it can be renamed in place. The new text is committed by
+// pressing Enter or moving focus away, and discarded by pressing Escape. An error is returned if the node's model
+// doesn't implement EditableModel.
+func (t *TreeContainer) StartRename(node *TreeNode) error {
+	if node == nil || node.treeContainer() != t {
+		return errors.New("node must be in this container")
+	}
+	if _, ok := node.model.(EditableModel); !ok {
+		return errors.New("node model is not editable")
+	}
+	t.commitRename(false)
+	t.mux.Lock()
+	t.renaming = node
+	t.mux.Unlock()
+
+	t.setCursor(node)
+	t.viewport.scrollTo(node)
+	editor := t.viewport.editor
+	editor.SetText(node.GetModelText())
+	t.Refresh()
+	if app := fyne.CurrentApp(); app != nil {
+		if c := app.Driver().CanvasForObject(t); c != nil {
+			c.Focus(editor)
+		}
+	}
+	return nil
+}
+
+// CancelRename closes the rename entry without changing the node.
+func (t *TreeContainer) CancelRename() {
+	if t.stopRename() != nil {
+		t.requestFocus()
+		t.Refresh()
+	}
+}
+
+// RenamingNode returns the node being renamed, or nil if no node is being renamed.
+func (t *TreeContainer) RenamingNode() *TreeNode {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.renaming
+}
+
+// IsRenaming returns whether this node is being renamed in its TreeContainer.
+func (n *TreeNode) IsRenaming() bool {
+	if c := n.treeContainer(); c != nil {
+		return c.RenamingNode() == n
+	}
+	return false
+}
+
+func (t *TreeContainer) stopRename() *TreeNode {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	node := t.renaming
+	t.renaming = nil
+	return node
+}
+
+// commitRename passes the entry's text to the model. If it was committed by a key press and the model rejects the text,
+// the entry stays open so the user can correct it, otherwise the rename is cancelled. Focus only returns to the
+// container after a key press, since the canvas is already moving focus elsewhere otherwise.
+func (t *TreeContainer) commitRename(fromKey bool) {
+	node := t.RenamingNode()
+	if node == nil {
+		return
+	}
+	oldText := node.GetModelText()
+	newText := t.viewport.editor.Text
+	if newText != oldText {
+		if err := node.model.(EditableModel).SetText(newText); err != nil {
+			if t.OnRenameError != nil {
+				t.OnRenameError(node, err)
+			} else {
+				fyne.LogError("Unable to rename node", err)
+			}
+			if fromKey {
+				return
+			}
+			newText = oldText
+		}
+	}
+	if t.stopRename() != node {
+		return
+	}
+	if fromKey {
+		t.requestFocus()
+	}
+	node.Refresh()
+	if newText != oldText {
+		t.record(&renameCommand{node: node, oldText: oldText, newText: newText})
+		if t.OnRenamed != nil {
+			t.OnRenamed(node, oldText, newText)
+		}
+	}
+}
+
+// renameTapWindow is how soon after the previous tap on a node's label it must be tapped again to start renaming it.
+// Tapping a node that was selected long ago only selects it again.
+const renameTapWindow = 2 * time.Second
+
+// renameOnTap starts renaming a node that was tapped while it was already the only selected node, if its label was
+// tapped shortly before.
+func (t *TreeContainer) renameOnTap(node *TreeNode, wasSelected bool) {
+	now := time.Now()
+	t.mux.Lock()
+	recent := t.lastTapped == node && now.Sub(t.lastTapTime) <= renameTapWindow
+	t.lastTapped = node
+	t.lastTapTime = now
+	t.mux.Unlock()
+	if !t.InlineRename || !wasSelected || !recent {
+		return
+	}
+	_ = t.StartRename(node)
+}
+
+// pruneRename cancels renaming a node that was removed, and forgets a removed node's last tap.
+func (t *TreeContainer) pruneRename(removed *TreeNode) {
+	t.mux.Lock()
+	if t.lastTapped == removed || (t.lastTapped != nil && t.lastTapped.isDescendantOf(removed)) {
+		t.lastTapped = nil
+	}
+	renaming := t.renaming
+	t.mux.Unlock()
+	if renaming == removed || (renaming != nil && renaming.isDescendantOf(removed)) {
+		t.CancelRename()
+	}
+}
+
+var _ fyne.Focusable = (*renameEntry)(nil)
+
+// renameEntry is shown over the label of the node being renamed.
+type renameEntry struct {
+	widget.Entry
+
+	tree *TreeContainer
+}
+
+func newRenameEntry(tree *TreeContainer) *renameEntry {
+	entry := &renameEntry{
+		tree: tree,
+	}
+	entry.ExtendBaseWidget(entry)
+	entry.Hide()
+	return entry
+}
+
+func (e *renameEntry) TypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeyReturn, fyne.KeyEnter:
+		e.tree.commitRename(true)
+	case fyne.KeyEscape:
+		e.tree.CancelRename()
+	default:
+		e.Entry.TypedKey(ev)
+	}
+}
+
+func (e *renameEntry) FocusLost() {
+	e.Entry.FocusLost()
+	e.tree.commitRename(false)
+}
@@ -0,0 +1,155 @@
+package fynetree
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne"
+)
+
+type editableModel struct {
+	StaticNodeModel
+}
+
+func (e *editableModel) SetText(text string) error {
+	if text == "" {
+		return errors.New("name must not be empty")
+	}
+	e.Text = text
+	return nil
+}
+
+func renameSetup() (*TreeNode, fyne.Window) {
+	containerSetup()
+	node := NewTreeNode(&editableModel{StaticNodeModel{Text: "Before"}})
+	node.SetLeaf()
+	_ = treeContainer.Append(nodeA)
+	_ = treeContainer.Append(node)
+	treeContainer.InlineRename = true
+	return node, showContainer()
+}
+
+// typeRename replaces the text in the focused rename editor and types the key.
+func typeRename(w fyne.Window, text string, key fyne.KeyName) {
+	focused := w.Canvas().Focused()
+	focused.(interface{ SetText(string) }).SetText(text)
+	focused.TypedKey(&fyne.KeyEvent{Name: key})
+}
+
+// editorShown returns whether the rename editor has focus.
+func editorShown(w fyne.Window) bool {
+	_, ok := w.Canvas().Focused().(*renameEntry)
+	return ok
+}
+
+func TestTreeContainer_RenameCommit(t *testing.T) {
+	node, w := renameSetup()
+	defer w.Close()
+	var renamed string
+	treeContainer.OnRenamed = func(_ *TreeNode, oldText, newText string) {
+		renamed = oldText + "->" + newText
+	}
+
+	if err := treeContainer.StartRename(node); err != nil {
+		t.Fatalf("Failed to start rename: %v", err)
+	}
+	if !node.IsRenaming() || !editorShown(w) {
+		t.Fatalf("Expected a focused editor to be shown for the node")
+	}
+
+	typeRename(w, "After", fyne.KeyReturn)
+	if node.GetModelText() != "After" || renamed != "Before->After" {
+		t.Fatalf("Expected node to be renamed, got '%s'", node.GetModelText())
+	}
+	if node.IsRenaming() || editorShown(w) {
+		t.Fatalf("Expected editor to be closed after commit")
+	}
+}
+
+func TestTreeContainer_RenameCancelAndReject(t *testing.T) {
+	node, w := renameSetup()
+	defer w.Close()
+	var rejected error
+	treeContainer.OnRenameError = func(_ *TreeNode, err error) {
+		rejected = err
+	}
+
+	_ = treeContainer.StartRename(node)
+	typeRename(w, "Cancelled", fyne.KeyEscape)
+	if node.GetModelText() != "Before" || node.IsRenaming() {
+		t.Fatalf("Expected escape to cancel the rename")
+	}
+
+	_ = treeContainer.StartRename(node)
+	typeRename(w, "", fyne.KeyEnter)
+	if rejected == nil || !node.IsRenaming() {
+		t.Fatalf("Expected rejected rename to keep the editor open")
+	}
+
+	w.Canvas().Focus(treeContainer)
+	if node.IsRenaming() || node.GetModelText() != "Before" {
+		t.Fatalf("Expected focus loss with rejected text to cancel the rename")
+	}
+}
+
+func TestTreeContainer_RenameCommitsOnFocusLoss(t *testing.T) {
+	node, w := renameSetup()
+	defer w.Close()
+	_ = treeContainer.StartRename(node)
+	w.Canvas().Focused().(*renameEntry).SetText("Focus")
+	w.Canvas().Unfocus()
+	if node.GetModelText() != "Focus" || node.IsRenaming() {
+		t.Fatalf("Expected focus loss to commit the rename")
+	}
+}
+
+func TestTreeContainer_RenameTriggers(t *testing.T) {
+	node, w := renameSetup()
+	defer w.Close()
+
+	if err := treeContainer.StartRename(nodeA); err == nil {
+		t.Fatalf("Expected error renaming a node without an EditableModel")
+	}
+
+	treeContainer.FocusNode(node)
+	treeContainer.TypedKey(&fyne.KeyEvent{Name: fyne.KeyF2})
+	if !node.IsRenaming() {
+		t.Fatalf("Expected F2 to start renaming")
+	}
+	treeContainer.CancelRename()
+
+	node.DoubleTapped(&fyne.PointEvent{})
+	if !node.IsRenaming() {
+		t.Fatalf("Expected double tap to start renaming")
+	}
+	treeContainer.CancelRename()
+
+	treeContainer.ClearSelection()
+	label := rowPart(treeContainer, node, RowPartLabel).(fyne.Tappable)
+	label.Tapped(&fyne.PointEvent{})
+	if node.IsRenaming() {
+		t.Fatalf("Expected first tap to only select the node")
+	}
+	label.Tapped(&fyne.PointEvent{})
+	if !node.IsRenaming() {
+		t.Fatalf("Expected tap on the selected node to start renaming")
+	}
+	treeContainer.CancelRename()
+
+	treeContainer.lastTapTime = time.Now().Add(-2 * renameTapWindow)
+	label.Tapped(&fyne.PointEvent{})
+	if node.IsRenaming() {
+		t.Fatalf("Expected a tap long after the previous one not to start renaming")
+	}
+}
+
+func TestTreeContainer_RenameRemovedNode(t *testing.T) {
+	node, w := renameSetup()
+	defer w.Close()
+	_ = treeContainer.StartRename(node)
+	_, _ = treeContainer.Remove(node)
+	if treeContainer.RenamingNode() != nil || editorShown(w) {
+		t.Fatalf("Expected removing the node to cancel renaming it")
+	}
+}
@@ -0,0 +1,50 @@
+package fynetree
+
+import "fyne.io/fyne"
+
+// RowPart is a part of a row drawn by a TreeContainer.
+type RowPart int
+
+const (
+	// RowPartNone is any object that isn't part of a row.
+	RowPartNone RowPart = iota
+	// RowPartRow is the whole row, which handles taps anywhere across its width.
+	RowPartRow
+	// RowPartHandle is the handle that expands and condenses the node.
+	RowPartHandle
+	// RowPartIcon is the node's icon.
+	RowPartIcon
+	// RowPartLabel is the label showing the node's text.
+	RowPartLabel
+	// RowPartCheck is the node's checkbox, shown when the container has Checkboxes.
+	RowPartCheck
+)
+
+// RowPartOf returns the node shown by an object drawn by a TreeContainer, and which part of its row the object is.
+// This lets tests and tools find the objects to interact with after walking the container's renderers. A nil node and
+// RowPartNone are returned for any other object.
+func RowPartOf(obj fyne.CanvasObject) (*TreeNode, RowPart) {
+	switch part := obj.(type) {
+	case *treeRow:
+		part.mux.Lock()
+		defer part.mux.Unlock()
+		return part.node, RowPartRow
+	case *expandHandle:
+		if part.node != nil {
+			return part.node, RowPartHandle
+		}
+	case *nodeIcon:
+		if part.node != nil {
+			return part.node, RowPartIcon
+		}
+	case *nodeLabel:
+		if part.node != nil {
+			return part.node, RowPartLabel
+		}
+	case *nodeCheck:
+		if part.node != nil {
+			return part.node, RowPartCheck
+		}
+	}
+	return nil, RowPartNone
+}
@@ -0,0 +1,30 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+)
+
+func TestRowPartOf(t *testing.T) {
+	row, w := rowSetup()
+	defer w.Close()
+	if node, part := RowPartOf(row); node != nodeA || part != RowPartRow {
+		t.Fatalf("Expected the row of node A, got part %d", part)
+	}
+	parts := map[RowPart]fyne.CanvasObject{
+		RowPartHandle: row.renderer.handle,
+		RowPartIcon:   row.renderer.icon,
+		RowPartLabel:  row.renderer.label,
+		RowPartCheck:  row.renderer.check,
+	}
+	for want, obj := range parts {
+		if node, part := RowPartOf(obj); node != nodeA || part != want {
+			t.Fatalf("Expected part %d of node A, got part %d", want, part)
+		}
+	}
+	if node, part := RowPartOf(canvas.NewRectangle(nil)); node != nil || part != RowPartNone {
+		t.Fatalf("Expected other objects not to be part of a row")
+	}
+}
@@ -0,0 +1,233 @@
+package fynetree
+
+import (
+	"fyne.io/fyne/driver/desktop"
+)
+
+// SelectionMode determines how a TreeContainer responds to selection requests.
+type SelectionMode int
+
+const (
+	// SelectionNone disables selection entirely.
+	SelectionNone SelectionMode = iota
+	// SelectionSingle allows at most one node to be selected at a time.
+	SelectionSingle
+	// SelectionMulti allows any set of nodes to be selected. Ctrl-tapping toggles a node, and shift-tapping selects a range of visible rows.
+	SelectionMulti
+	// SelectionRange allows one contiguous range of visible rows to be selected by shift-tapping.
+	SelectionRange
+)
+
+// SelectionChangedHandler is a handler function that receives the full selection after it changes.
+type SelectionChangedHandler func(selected []*TreeNode)
+
+// SelectedNodes returns the currently selected nodes in the order they were selected.
+func (t *TreeContainer) SelectedNodes() []*TreeNode {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	selected := make([]*TreeNode, len(t.selected))
+	copy(selected, t.selected)
+	return selected
+}
+
+// IsSelected returns whether the given node is currently selected in this container.
+func (t *TreeContainer) IsSelected(node *TreeNode) bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.indexOfSelected(node) >= 0
+}
+
+// Select adds the node to the selection. In single and range modes the node replaces the current selection.
+func (t *TreeContainer) Select(node *TreeNode) {
+	if node == nil || node.treeContainer() != t {
+		return
+	}
+	switch t.SelectionMode {
+	case SelectionNone:
+		return
+	case SelectionMulti:
+		t.mux.Lock()
+		if t.indexOfSelected(node) >= 0 {
+			t.mux.Unlock()
+			return
+		}
+		selected := append(t.copySelected(), node)
+		t.mux.Unlock()
+		t.setSelection(selected, node)
+	default:
+		t.setSelection([]*TreeNode{node}, node)
+	}
+}
+
+// Deselect removes the node from the selection if it's selected.
+func (t *TreeContainer) Deselect(node *TreeNode) {
+	t.mux.Lock()
+	i := t.indexOfSelected(node)
+	if i < 0 {
+		t.mux.Unlock()
+		return
+	}
+	selected := t.copySelected()
+	selected = append(selected[:i], selected[i+1:]...)
+	anchor := t.anchor
+	if anchor == node {
+		anchor = nil
+	}
+	t.mux.Unlock()
+	t.setSelection(selected, anchor)
+}
+
+// SelectRange selects every visible row between from and to inclusive, replacing the current selection.
+// Nothing is changed if the selection mode doesn't allow more than one node, or if either node isn't visible.
+func (t *TreeContainer) SelectRange(from, to *TreeNode) {
+	if t.SelectionMode != SelectionMulti && t.SelectionMode != SelectionRange {
+		return
+	}
+	selected := t.visibleRange(from, to)
+	if selected == nil {
+		return
+	}
+	t.setSelection(selected, from)
+}
+
+// ClearSelection deselects all nodes.
+func (t *TreeContainer) ClearSelection() {
+	t.setSelection(nil, nil)
+}
+
+// nodeTapped applies a user tap on the given node to the selection, taking held modifier keys into account.
+func (t *TreeContainer) nodeTapped(node *TreeNode, modifier desktop.Modifier) {
+	toggle := modifier&(desktop.ControlModifier|desktop.SuperModifier) != 0
+	extend := modifier&desktop.ShiftModifier != 0
+
+	t.mux.Lock()
+	anchor := t.anchor
+	t.mux.Unlock()
+
+	switch t.SelectionMode {
+	case SelectionNone:
+		return
+	case SelectionMulti:
+		if extend && anchor != nil {
+			selected := t.visibleRange(anchor, node)
+			if selected == nil {
+				break
+			}
+			if toggle {
+				t.mux.Lock()
+				for _, s := range t.selected {
+					if indexOfNode(selected, s) < 0 {
+						selected = append(selected, s)
+					}
+				}
+				t.mux.Unlock()
+			}
+			t.setSelection(selected, anchor)
+			return
+		}
+		if toggle {
+			if t.IsSelected(node) {
+				t.Deselect(node)
+				t.mux.Lock()
+				t.anchor = node
+				t.mux.Unlock()
+			} else {
+				t.Select(node)
+			}
+			return
+		}
+	case SelectionRange:
+		if extend && anchor != nil {
+			if selected := t.visibleRange(anchor, node); selected != nil {
+				t.setSelection(selected, anchor)
+				return
+			}
+		}
+	}
+	t.setSelection([]*TreeNode{node}, node)
+}
+
+// visibleRange returns the visible rows between the two nodes inclusive, or nil if either isn't visible.
+func (t *TreeContainer) visibleRange(from, to *TreeNode) []*TreeNode {
+	visible := t.visibleNodes()
+	start := indexOfNode(visible, from)
+	end := indexOfNode(visible, to)
+	if start < 0 || end < 0 {
+		return nil
+	}
+	if start > end {
+		start, end = end, start
+	}
+	selected := make([]*TreeNode, end-start+1)
+	copy(selected, visible[start:end+1])
+	return selected
+}
+
+// setSelection replaces the selection, refreshes the rows, and notifies OnSelectionChanged if anything changed.
+func (t *TreeContainer) setSelection(selected []*TreeNode, anchor *TreeNode) {
+	t.mux.Lock()
+	previous := t.selected
+	t.selected = selected
+	t.anchor = anchor
+	t.mux.Unlock()
+
+	changed := len(previous) != len(selected)
+	for _, n := range selected {
+		if indexOfNode(previous, n) < 0 {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+	t.Refresh()
+	if t.OnSelectionChanged != nil {
+		t.OnSelectionChanged(t.SelectedNodes())
+	}
+}
+
+// pruneSelection removes the given node and any of its descendants from the selection after it's removed from the tree.
+func (t *TreeContainer) pruneSelection(removed *TreeNode) {
+	t.mux.Lock()
+	var selected []*TreeNode
+	pruned := false
+	for _, s := range t.selected {
+		if s == removed || s.isDescendantOf(removed) {
+			pruned = true
+			continue
+		}
+		selected = append(selected, s)
+	}
+	anchor := t.anchor
+	if anchor == removed || (anchor != nil && anchor.isDescendantOf(removed)) {
+		anchor = nil
+	}
+	t.mux.Unlock()
+	if pruned {
+		t.setSelection(selected, anchor)
+	} else {
+		t.mux.Lock()
+		t.anchor = anchor
+		t.mux.Unlock()
+	}
+}
+
+func (t *TreeContainer) indexOfSelected(node *TreeNode) int {
+	return indexOfNode(t.selected, node)
+}
+
+func (t *TreeContainer) copySelected() []*TreeNode {
+	selected := make([]*TreeNode, len(t.selected))
+	copy(selected, t.selected)
+	return selected
+}
+
+func indexOfNode(nodes []*TreeNode, node *TreeNode) int {
+	for i, n := range nodes {
+		if n == node {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,132 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+)
+
+func selectionSetup() {
+	containerSetup()
+	_ = treeContainer.Append(nodeA)
+	_ = treeContainer.Append(nodeB)
+	_ = treeContainer.Append(nodeC)
+	_ = nodeB.Append(nodeD)
+	nodeB.Expand()
+}
+
+func tapWithModifier(node *TreeNode, modifier desktop.Modifier) {
+	node.tapModifier = modifier
+	node.tapped(&fyne.PointEvent{})
+}
+
+func assertSelection(t *testing.T, want ...*TreeNode) {
+	t.Helper()
+	got := treeContainer.SelectedNodes()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d selected nodes, got %d", len(want), len(got))
+	}
+	for _, n := range want {
+		if !n.IsSelected() {
+			t.Fatalf("Expected node '%s' to be selected", n.GetModelText())
+		}
+	}
+}
+
+func TestTreeContainer_SelectSingle(t *testing.T) {
+	selectionSetup()
+	var notified int
+	treeContainer.OnSelectionChanged = func(_ []*TreeNode) {
+		notified++
+	}
+
+	tapWithModifier(nodeA, 0)
+	assertSelection(t, nodeA)
+	tapWithModifier(nodeD, desktop.ControlModifier)
+	assertSelection(t, nodeD)
+	tapWithModifier(nodeD, 0)
+	assertSelection(t, nodeD)
+
+	if notified != 2 {
+		t.Fatalf("Expected 2 selection change notifications, got %d", notified)
+	}
+}
+
+func TestTreeContainer_SelectMulti(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionMulti
+
+	tapWithModifier(nodeA, 0)
+	tapWithModifier(nodeC, desktop.ControlModifier)
+	assertSelection(t, nodeA, nodeC)
+
+	tapWithModifier(nodeA, desktop.ControlModifier)
+	assertSelection(t, nodeC)
+
+	tapWithModifier(nodeD, desktop.ShiftModifier)
+	assertSelection(t, nodeA, nodeB, nodeD)
+
+	tapWithModifier(nodeC, desktop.ShiftModifier|desktop.ControlModifier)
+	assertSelection(t, nodeA, nodeB, nodeD, nodeC)
+}
+
+func TestTreeContainer_SelectRange(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionRange
+
+	tapWithModifier(nodeD, 0)
+	tapWithModifier(nodeA, desktop.ShiftModifier)
+	assertSelection(t, nodeA, nodeB, nodeD)
+
+	tapWithModifier(nodeC, desktop.ControlModifier)
+	assertSelection(t, nodeC)
+
+	nodeB.Condense()
+	treeContainer.SelectRange(nodeA, nodeC)
+	assertSelection(t, nodeA, nodeB, nodeC)
+}
+
+func TestTreeContainer_SelectNone(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionNone
+
+	tapWithModifier(nodeA, 0)
+	treeContainer.Select(nodeB)
+	assertSelection(t)
+}
+
+func TestTreeContainer_SelectProgrammatic(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionMulti
+
+	treeContainer.Select(nodeA)
+	treeContainer.Select(nodeD)
+	assertSelection(t, nodeA, nodeD)
+
+	treeContainer.Deselect(nodeA)
+	assertSelection(t, nodeD)
+
+	treeContainer.Select(NewTreeNode(NewStaticModel(nil, "Outside")))
+	assertSelection(t, nodeD)
+
+	treeContainer.ClearSelection()
+	assertSelection(t)
+}
+
+func TestTreeContainer_SelectionPrunedOnRemoval(t *testing.T) {
+	selectionSetup()
+	treeContainer.SelectionMode = SelectionMulti
+	treeContainer.Select(nodeA)
+	treeContainer.Select(nodeD)
+
+	if _, err := treeContainer.Remove(nodeB); err != nil {
+		t.Fatalf("Failed to remove node B: %v", err)
+	}
+	assertSelection(t, nodeA)
+
+	if _, err := treeContainer.Remove(nodeA); err != nil {
+		t.Fatalf("Failed to remove node A: %v", err)
+	}
+	assertSelection(t)
+}
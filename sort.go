@@ -0,0 +1,272 @@
+package fynetree
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"fyne.io/fyne"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// NodeComparator compares two nodes for sorting, and returns a negative number if a sorts before b, a positive number
+// if a sorts after b, or zero if they're equal.
+type NodeComparator func(a, b *TreeNode) int
+
+// CaseInsensitiveCompare compares the nodes' text ignoring case. This is the default comparator.
+func CaseInsensitiveCompare(a, b *TreeNode) int {
+	return strings.Compare(strings.ToUpper(a.GetModelText()), strings.ToUpper(b.GetModelText()))
+}
+
+// NaturalCompare compares the nodes' text ignoring case, treating runs of digits as numbers so "9" sorts before "10".
+func NaturalCompare(a, b *TreeNode) int {
+	return naturalCompare(a.GetModelText(), b.GetModelText())
+}
+
+// LocaleComparator creates a NodeComparator that compares the nodes' text using the collation rules of the given
+// language. Options such as collate.IgnoreCase and collate.Numeric may be passed to change how text is compared.
+func LocaleComparator(tag language.Tag, opts ...collate.Option) NodeComparator {
+	var mux sync.Mutex
+	collator := collate.New(tag, opts...)
+	return func(a, b *TreeNode) int {
+		mux.Lock()
+		defer mux.Unlock()
+		return collator.CompareString(a.GetModelText(), b.GetModelText())
+	}
+}
+
+func naturalCompare(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			startA, startB := i, j
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+			numA := strings.TrimLeft(string(ra[startA:i]), "0")
+			numB := strings.TrimLeft(string(rb[startB:j]), "0")
+			if len(numA) != len(numB) {
+				return len(numA) - len(numB)
+			}
+			if c := strings.Compare(numA, numB); c != 0 {
+				return c
+			}
+			continue
+		}
+		ca, cb := unicode.ToUpper(ra[i]), unicode.ToUpper(rb[j])
+		if ca != cb {
+			return int(ca) - int(cb)
+		}
+		i++
+		j++
+	}
+	return (len(ra) - i) - (len(rb) - j)
+}
+
+// SetComparator sets the comparator used to sort this node's children. A nil comparator uses CaseInsensitiveCompare.
+// Children are sorted again if the node is auto-sorted.
+func (n *TreeNode) SetComparator(compare NodeComparator) {
+	if n.nodeList.setComparator(compare) {
+		n.Refresh()
+	}
+}
+
+// SetAutoSort sets whether this node keeps its children sorted. While enabled, children are sorted immediately, added
+// children are inserted in sorted order, and children with an ObservableModel are moved when their model changes.
+func (n *TreeNode) SetAutoSort(enabled bool) {
+	if n.nodeList.setAutoSort(enabled) {
+		n.Refresh()
+	}
+}
+
+// SortChildren sorts this node's children with its comparator, and optionally all of their descendants with theirs.
+// The sort is recorded in the container's history, so it can be undone.
+func (n *TreeNode) SortChildren(recursive bool) {
+	recordSort(n.history(), n.nodeList.withDescendants(recursive), (*nodeList).sortObjects, n.Refresh)
+}
+
+// SetComparator sets the comparator used to sort the root nodes. A nil comparator uses CaseInsensitiveCompare.
+// The roots are sorted again if the container is auto-sorted.
+func (t *TreeContainer) SetComparator(compare NodeComparator) {
+	if t.nodeList.setComparator(compare) {
+		t.Refresh()
+	}
+}
+
+// SetAutoSort sets whether the container keeps its root nodes sorted, the same way as TreeNode.SetAutoSort.
+func (t *TreeContainer) SetAutoSort(enabled bool) {
+	if t.nodeList.setAutoSort(enabled) {
+		t.Refresh()
+	}
+}
+
+// SortChildren sorts the root nodes with the container's comparator, and optionally all of their descendants with theirs.
+// The sort is recorded in the container's history, so it can be undone.
+func (t *TreeContainer) SortChildren(recursive bool) {
+	recordSort(t.History, t.nodeList.withDescendants(recursive), (*nodeList).sortObjects, t.Refresh)
+}
+
+// IsAutoSorted returns whether nodes are inserted in sorted order regardless of how they're added.
+func (n *nodeList) IsAutoSorted() bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.autoSort
+}
+
+func (n *nodeList) getComparator() NodeComparator {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if n.comparator == nil {
+		return CaseInsensitiveCompare
+	}
+	return n.comparator
+}
+
+// setComparator changes the comparator, and returns whether the list was sorted again.
+func (n *nodeList) setComparator(compare NodeComparator) bool {
+	n.mux.Lock()
+	n.comparator = compare
+	autoSort := n.autoSort
+	n.mux.Unlock()
+	if autoSort {
+		n.sortObjects()
+	}
+	return autoSort
+}
+
+// setAutoSort enables or disables auto-sorting, and returns whether the list was sorted.
+func (n *nodeList) setAutoSort(enabled bool) bool {
+	n.mux.Lock()
+	n.autoSort = enabled
+	n.mux.Unlock()
+	if enabled {
+		n.sortObjects()
+	}
+	return enabled
+}
+
+// withDescendants returns this list, followed by the lists of all of its descendants if recursive.
+func (n *nodeList) withDescendants(recursive bool) []*nodeList {
+	lists := []*nodeList{n}
+	if !recursive {
+		return lists
+	}
+	for _, obj := range n.order() {
+		if child, ok := obj.(*TreeNode); ok {
+			lists = append(lists, child.nodeList.withDescendants(true)...)
+		}
+	}
+	return lists
+}
+
+// sortObjects stably sorts the list in place with its comparator, without triggering any addition or removal hooks.
+func (n *nodeList) sortObjects() {
+	n.sortWith(n.getComparator())
+}
+
+// sortWith stably sorts the list in place with the given comparator, keeping the list's own comparator.
+func (n *nodeList) sortWith(compare NodeComparator) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	sort.SliceStable(n.Objects, func(i, j int) bool {
+		a, okA := n.Objects[i].(*TreeNode)
+		b, okB := n.Objects[j].(*TreeNode)
+		return okA && okB && compare(a, b) < 0
+	})
+}
+
+// resortInParent moves this node into sorted position if the list holding it is auto-sorted.
+func (n *TreeNode) resortInParent() {
+	var list *nodeList
+	if n.parent != nil {
+		list = n.parent.nodeList
+	} else if n.container != nil {
+		list = n.container.nodeList
+	}
+	if list == nil || !list.IsAutoSorted() {
+		return
+	}
+	list.sortObjects()
+	if n.parent != nil {
+		n.parent.Refresh()
+	}
+}
+
+// order returns a copy of the list's objects in their current order.
+func (n *nodeList) order() []fyne.CanvasObject {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return append([]fyne.CanvasObject(nil), n.Objects...)
+}
+
+// setOrder puts the list's objects in the given order, unless they've been added or removed since the order was
+// taken.
+func (n *nodeList) setOrder(order []fyne.CanvasObject) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if len(order) != len(n.Objects) {
+		return
+	}
+	present := make(map[fyne.CanvasObject]bool, len(n.Objects))
+	for _, obj := range n.Objects {
+		present[obj] = true
+	}
+	for _, obj := range order {
+		if !present[obj] {
+			return
+		}
+	}
+	copy(n.Objects, order)
+}
+
+// recordSort sorts each list, refreshes, and records the change in the history if there is one and the order changed.
+func recordSort(history *History, lists []*nodeList, sort func(list *nodeList), refresh func()) {
+	cmd := &sortCommand{lists: lists, refresh: refresh}
+	changed := false
+	for _, list := range lists {
+		before := list.order()
+		sort(list)
+		after := list.order()
+		cmd.before = append(cmd.before, before)
+		cmd.after = append(cmd.after, after)
+		for i := range before {
+			changed = changed || before[i] != after[i]
+		}
+	}
+	refresh()
+	if history != nil && changed {
+		history.Record(cmd)
+	}
+}
+
+// sortCommand records the children of lists being sorted.
+type sortCommand struct {
+	lists   []*nodeList
+	before  [][]fyne.CanvasObject
+	after   [][]fyne.CanvasObject
+	refresh func()
+}
+
+func (c *sortCommand) Undo() error {
+	c.setOrders(c.before)
+	return nil
+}
+
+func (c *sortCommand) Redo() error {
+	c.setOrders(c.after)
+	return nil
+}
+
+func (c *sortCommand) setOrders(orders [][]fyne.CanvasObject) {
+	for i, list := range c.lists {
+		list.setOrder(orders[i])
+	}
+	c.refresh()
+}
@@ -0,0 +1,151 @@
+package fynetree
+
+import (
+	"testing"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func textNodes(texts ...string) []*TreeNode {
+	nodes := make([]*TreeNode, len(texts))
+	for i, text := range texts {
+		nodes[i] = NewTreeNode(NewStaticModel(nil, text))
+	}
+	return nodes
+}
+
+func assertChildOrder(t *testing.T, list *nodeList, want ...string) {
+	t.Helper()
+	if list.Len() != len(want) {
+		t.Fatalf("Expected %d children, got %d", len(want), list.Len())
+	}
+	for i, text := range want {
+		if got := list.Objects[i].(*TreeNode).GetModelText(); got != text {
+			t.Fatalf("Expected child %d to be '%s', got '%s'", i, text, got)
+		}
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		less bool
+	}{
+		{"9", "10", true},
+		{"file2", "file10", true},
+		{"File10", "file9", false},
+		{"a", "B", true},
+		{"x007", "x7y", true},
+		{"abc", "ab", false},
+	}
+	for _, tc := range tests {
+		if got := naturalCompare(tc.a, tc.b) < 0; got != tc.less {
+			t.Fatalf("Expected '%s' < '%s' to be %v", tc.a, tc.b, tc.less)
+		}
+	}
+}
+
+func TestNodeList_InsertSortedFunc(t *testing.T) {
+	listSetup()
+	for _, n := range textNodes("10", "9", "100", "1") {
+		if err := list.InsertSortedFunc(n, NaturalCompare); err != nil {
+			t.Fatalf("Failed to insert node: %v", err)
+		}
+	}
+	assertChildOrder(t, list, "1", "9", "10", "100")
+
+	tie := textNodes("10")[0]
+	_ = list.InsertSortedFunc(tie, NaturalCompare)
+	if list.Objects[2] != tie {
+		t.Fatalf("Expected a node to be inserted before the nodes it ties with")
+	}
+}
+
+func TestLocaleComparator(t *testing.T) {
+	listSetup()
+	compare := LocaleComparator(language.German, collate.IgnoreCase)
+	for _, n := range textNodes("Zebra", "Äpfel", "apfel", "Birne") {
+		_ = list.InsertSortedFunc(n, compare)
+	}
+	assertChildOrder(t, list, "apfel", "Äpfel", "Birne", "Zebra")
+}
+
+func TestTreeNode_SortChildren(t *testing.T) {
+	treeNodeSetup()
+	for _, n := range textNodes("b10", "b9", "a") {
+		_ = rootNode.Append(n)
+	}
+	child := rootNode.Objects[0].(*TreeNode)
+	for _, n := range textNodes("y", "x") {
+		_ = child.Append(n)
+	}
+
+	rootNode.SetComparator(NaturalCompare)
+	rootNode.SortChildren(false)
+	assertChildOrder(t, rootNode.nodeList, "a", "b9", "b10")
+	assertChildOrder(t, child.nodeList, "y", "x")
+
+	rootNode.SortChildren(true)
+	assertChildOrder(t, child.nodeList, "x", "y")
+}
+
+func TestTreeContainer_SortChildrenHistory(t *testing.T) {
+	containerSetup()
+	for _, n := range textNodes("b", "a") {
+		_ = treeContainer.Append(n)
+	}
+	child := treeContainer.Objects[0].(*TreeNode)
+	for _, n := range textNodes("y", "x") {
+		_ = child.Append(n)
+	}
+	treeContainer.History = NewHistory()
+
+	treeContainer.SortChildren(true)
+	assertChildOrder(t, treeContainer.nodeList, "a", "b")
+	assertChildOrder(t, child.nodeList, "x", "y")
+	if err := treeContainer.Undo(); err != nil {
+		t.Fatalf("Failed to undo the sort: %v", err)
+	}
+	assertChildOrder(t, treeContainer.nodeList, "b", "a")
+	assertChildOrder(t, child.nodeList, "y", "x")
+	_ = treeContainer.Redo()
+	assertChildOrder(t, treeContainer.nodeList, "a", "b")
+	assertChildOrder(t, child.nodeList, "x", "y")
+
+	child.SortChildren(false)
+	_ = treeContainer.Undo()
+	if treeContainer.History.CanUndo() {
+		t.Fatalf("Expected a sort that doesn't change the order not to be recorded")
+	}
+	assertChildOrder(t, treeContainer.nodeList, "b", "a")
+}
+
+func TestTreeNode_AutoSort(t *testing.T) {
+	treeNodeSetup()
+	for _, n := range textNodes("c", "a") {
+		_ = rootNode.Append(n)
+	}
+	rootNode.SetAutoSort(true)
+	if !rootNode.IsAutoSorted() {
+		t.Fatalf("Expected node to be auto-sorted")
+	}
+	assertChildOrder(t, rootNode.nodeList, "a", "c")
+
+	nodes := textNodes("d", "b")
+	_ = rootNode.Append(nodes[0])
+	if err := rootNode.InsertAt(0, nodes[1]); err == nil {
+		t.Fatalf("Expected error inserting at a position in an auto-sorted list")
+	}
+	_ = rootNode.InsertSorted(nodes[1])
+	assertChildOrder(t, rootNode.nodeList, "a", "b", "c", "d")
+
+	model := newObservableModel("e")
+	_ = rootNode.Append(NewTreeNode(model))
+	model.setText("0")
+	assertChildOrder(t, rootNode.nodeList, "0", "a", "b", "c", "d")
+
+	rootNode.SetAutoSort(false)
+	_ = rootNode.InsertAt(0, textNodes("z")[0])
+	assertChildOrder(t, rootNode.nodeList, "z", "0", "a", "b", "c", "d")
+}
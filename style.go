@@ -0,0 +1,125 @@
+package fynetree
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+	"github.com/drognisep/fynetree/util"
+)
+
+// Density controls how tightly rows are packed.
+type Density int
+
+const (
+	// DensityComfortable gives each row's text the theme's normal padding.
+	DensityComfortable Density = iota
+	// DensityCompact halves the padding around each row's text, fitting more rows in view.
+	DensityCompact
+)
+
+// TreeStyle controls how a TreeContainer lays out its rows. Zero values use the defaults, so a style only needs to set
+// the values it changes. The container must be refreshed after its style is changed.
+type TreeStyle struct {
+	// Indent is how far each level of the tree is indented. Defaults to HierarchyPadding.
+	Indent int
+	// ExpandIcon is shown in the handle of a condensed branch. Defaults to theme.MenuExpandIcon.
+	ExpandIcon fyne.Resource
+	// CondenseIcon is shown in the handle of an expanded branch. Defaults to theme.MenuDropDownIcon.
+	CondenseIcon fyne.Resource
+	// IconSize is the width and height of the handle, checkbox and node icons. Defaults to theme.IconInlineSize.
+	IconSize int
+	// RowPadding is extra space added above and below each row.
+	RowPadding int
+	// Density controls the padding around each row's text.
+	Density Density
+	// GuideLines draws lines joining each node to its parent.
+	GuideLines bool
+}
+
+var chevronDownIcon = theme.NewThemedResource(fyne.NewStaticResource("chevron-down.svg", []byte(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">`+
+		`<path d="M16.59 8.59L12 13.17 7.41 8.59 6 10l6 6 6-6z"/>`+
+		`</svg>`)), nil)
+
+// ChevronStyle returns a style that uses chevrons for the expand handle.
+func ChevronStyle() TreeStyle {
+	return TreeStyle{
+		ExpandIcon:   theme.NavigateNextIcon(),
+		CondenseIcon: chevronDownIcon,
+	}
+}
+
+// PlusMinusStyle returns a style that uses plus and minus signs for the expand handle.
+func PlusMinusStyle() TreeStyle {
+	return TreeStyle{
+		ExpandIcon:   theme.ContentAddIcon(),
+		CondenseIcon: theme.ContentRemoveIcon(),
+	}
+}
+
+// resolved returns a copy of the style with defaults in place of zero values. Theme values are looked up each time,
+// so the style follows theme changes.
+func (s TreeStyle) resolved() TreeStyle {
+	if s.Indent <= 0 {
+		s.Indent = HierarchyPadding
+	}
+	if s.ExpandIcon == nil {
+		s.ExpandIcon = theme.MenuExpandIcon()
+	}
+	if s.CondenseIcon == nil {
+		s.CondenseIcon = theme.MenuDropDownIcon()
+	}
+	if s.IconSize <= 0 {
+		s.IconSize = theme.IconInlineSize()
+	}
+	s.RowPadding = util.IntMax(s.RowPadding, 0)
+	return s
+}
+
+// textHeight returns the height given to a row's text, which has the given minimum height.
+func (s TreeStyle) textHeight(labelHeight int) int {
+	if s.Density == DensityCompact {
+		return labelHeight - theme.Padding()
+	}
+	return labelHeight
+}
+
+// rowHeight returns the height of a row whose text has the given minimum height.
+func (s TreeStyle) rowHeight(labelHeight int) int {
+	return util.IntMax(s.textHeight(labelHeight), s.IconSize) + 2*s.RowPadding
+}
+
+// style returns the container's style with defaults filled in.
+func (t *TreeContainer) style() TreeStyle {
+	if t == nil {
+		return TreeStyle{}.resolved()
+	}
+	return t.Style.resolved()
+}
+
+// SetStyle sets the style used to show this node and its descendants when the node is shown on its own rather than in
+// a TreeContainer, in which case the container's style is used.
+func (n *TreeNode) SetStyle(style TreeStyle) {
+	n.mux.Lock()
+	n.style = &style
+	n.mux.Unlock()
+	n.Refresh()
+}
+
+// nodeStyle returns the style of the container the node is in. Nodes that aren't in a container use the style set on
+// their root, or the default style.
+func nodeStyle(node *TreeNode) TreeStyle {
+	if node == nil {
+		return TreeStyle{}.resolved()
+	}
+	root := node.Root()
+	if root.container != nil {
+		return root.container.style()
+	}
+	root.mux.Lock()
+	style := root.style
+	root.mux.Unlock()
+	if style != nil {
+		return style.resolved()
+	}
+	return TreeStyle{}.resolved()
+}
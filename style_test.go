@@ -0,0 +1,58 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne/theme"
+)
+
+func TestTreeStyle_Defaults(t *testing.T) {
+	style := TreeStyle{}.resolved()
+	if style.Indent != HierarchyPadding || style.IconSize != theme.IconInlineSize() {
+		t.Fatalf("Expected default indent and icon size, got %d and %d", style.Indent, style.IconSize)
+	}
+	if style.ExpandIcon != theme.MenuExpandIcon() || style.CondenseIcon != theme.MenuDropDownIcon() {
+		t.Fatalf("Expected default handle icons")
+	}
+}
+
+func TestTreeStyle_RowLayout(t *testing.T) {
+	traversalSetup()
+	rootNode.Expand()
+	w := showContainer()
+	defer w.Close()
+
+	comfortable := treeContainer.viewport.rowHeight()
+	treeContainer.Style = TreeStyle{Indent: 40, IconSize: 32, RowPadding: 4}
+	treeContainer.Refresh()
+	if got := treeContainer.viewport.rowHeight(); got != 32+2*4 {
+		t.Fatalf("Expected row height from icon size and padding, got %d", got)
+	}
+	row := rowPart(treeContainer, nodeA, RowPartRow).(*treeRow)
+	if handle := rowPart(row, nodeA, RowPartHandle); handle.Position().X != 40 || handle.Size().Width != 32 {
+		t.Fatalf("Expected A's handle to be indented and sized by the style")
+	}
+	if got := row.labelOffset(); got != 40+32 {
+		t.Fatalf("Expected label after the handle, got %d", got)
+	}
+
+	treeContainer.Style = TreeStyle{Density: DensityCompact}
+	treeContainer.Refresh()
+	if got := treeContainer.viewport.rowHeight(); got >= comfortable {
+		t.Fatalf("Expected compact rows to be shorter than %d, got %d", comfortable, got)
+	}
+}
+
+func TestTreeStyle_HandleIcons(t *testing.T) {
+	traversalSetup()
+	treeContainer.Style = PlusMinusStyle()
+	handle := NewExpandHandle(nodeA)
+	if handle.Resource != theme.ContentAddIcon() {
+		t.Fatalf("Expected plus icon for a condensed node")
+	}
+	nodeA.Expand()
+	handle.Refresh()
+	if handle.Resource != theme.ContentRemoveIcon() {
+		t.Fatalf("Expected minus icon for an expanded node")
+	}
+}
@@ -0,0 +1,277 @@
+package fynetree
+
+import (
+	"image/color"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/theme"
+	"fyne.io/fyne/widget"
+	"github.com/drognisep/fynetree/util"
+)
+
+const headerDividerWidth = 6
+
+var _ fyne.Widget = (*tableHeader)(nil)
+
+// tableHeader shows a TreeTable's column titles. Tapping a title sorts by that column, and dragging the divider at the
+// right edge of a column resizes it.
+type tableHeader struct {
+	widget.BaseWidget
+
+	table *TreeTable
+}
+
+func newTableHeader(table *TreeTable) *tableHeader {
+	header := &tableHeader{
+		table: table,
+	}
+	header.ExtendBaseWidget(header)
+	return header
+}
+
+func (h *tableHeader) CreateRenderer() fyne.WidgetRenderer {
+	renderer := &tableHeaderRenderer{
+		header:     h,
+		background: canvas.NewRectangle(theme.ButtonColor()),
+	}
+	renderer.Refresh()
+	return renderer
+}
+
+var _ fyne.WidgetRenderer = (*tableHeaderRenderer)(nil)
+
+type tableHeaderRenderer struct {
+	header     *tableHeader
+	background *canvas.Rectangle
+	cells      []*headerCell
+	dividers   []*headerDivider
+}
+
+func (r *tableHeaderRenderer) Layout(size fyne.Size) {
+	r.background.Move(fyne.NewPos(0, 0))
+	r.background.Resize(size)
+	columns := r.header.table.Columns()
+	var x int
+	for i, cell := range r.cells {
+		if i >= len(columns) {
+			break
+		}
+		width := columns[i].Width
+		cell.Move(fyne.NewPos(x, 0))
+		cell.Resize(fyne.NewSize(width, size.Height))
+		x += width
+		divider := r.dividers[i]
+		divider.Move(fyne.NewPos(x-headerDividerWidth/2, 0))
+		divider.Resize(fyne.NewSize(headerDividerWidth, size.Height))
+	}
+}
+
+func (r *tableHeaderRenderer) MinSize() fyne.Size {
+	var width, height int
+	for i, column := range r.header.table.Columns() {
+		width += column.Width
+		if i < len(r.cells) {
+			height = util.IntMax(height, r.cells[i].MinSize().Height)
+		}
+	}
+	return fyne.NewSize(width, height)
+}
+
+func (r *tableHeaderRenderer) Refresh() {
+	columns := r.header.table.Columns()
+	for len(r.cells) < len(columns) {
+		i := len(r.cells)
+		r.cells = append(r.cells, newHeaderCell(r.header.table, i))
+		r.dividers = append(r.dividers, newHeaderDivider(r.header.table, i))
+	}
+	r.cells = r.cells[:len(columns)]
+	r.dividers = r.dividers[:len(columns)]
+
+	sortColumn, ascending := r.header.table.SortColumn()
+	for i, cell := range r.cells {
+		cell.label.SetText(columns[i].Title)
+		cell.label.Alignment = columns[i].Alignment
+		if i == 0 {
+			cell.label.Alignment = fyne.TextAlignLeading
+		}
+		if i == sortColumn && ascending {
+			cell.icon.SetResource(theme.MenuDropUpIcon())
+			cell.icon.Show()
+		} else if i == sortColumn {
+			cell.icon.SetResource(theme.MenuDropDownIcon())
+			cell.icon.Show()
+		} else {
+			cell.icon.Hide()
+		}
+		cell.Refresh()
+	}
+	r.background.FillColor = theme.ButtonColor()
+	r.Layout(r.header.Size())
+	canvas.Refresh(r.header)
+}
+
+func (r *tableHeaderRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (r *tableHeaderRenderer) Objects() []fyne.CanvasObject {
+	objects := make([]fyne.CanvasObject, 0, len(r.cells)+len(r.dividers)+1)
+	objects = append(objects, r.background)
+	for _, cell := range r.cells {
+		objects = append(objects, cell)
+	}
+	for _, divider := range r.dividers {
+		objects = append(objects, divider)
+	}
+	return objects
+}
+
+func (r *tableHeaderRenderer) Destroy() {
+	r.cells = nil
+	r.dividers = nil
+}
+
+var _ fyne.Tappable = (*headerCell)(nil)
+
+// headerCell shows a column's title and sort direction, and sorts by the column when tapped.
+type headerCell struct {
+	widget.BaseWidget
+
+	table  *TreeTable
+	column int
+	label  *widget.Label
+	icon   *widget.Icon
+}
+
+func newHeaderCell(table *TreeTable, column int) *headerCell {
+	cell := &headerCell{
+		table:  table,
+		column: column,
+		label:  widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		icon:   widget.NewIcon(nil),
+	}
+	cell.icon.Hide()
+	cell.ExtendBaseWidget(cell)
+	return cell
+}
+
+func (c *headerCell) Tapped(_ *fyne.PointEvent) {
+	c.table.toggleSort(c.column)
+}
+
+func (c *headerCell) CreateRenderer() fyne.WidgetRenderer {
+	return &headerCellRenderer{cell: c}
+}
+
+type headerCellRenderer struct {
+	cell *headerCell
+}
+
+func (r *headerCellRenderer) Layout(size fyne.Size) {
+	iconSize := r.cell.icon.MinSize()
+	labelWidth := size.Width
+	if r.cell.icon.Visible() {
+		labelWidth -= iconSize.Width
+		r.cell.icon.Move(fyne.NewPos(labelWidth, (size.Height-iconSize.Height)/2))
+		r.cell.icon.Resize(iconSize)
+	}
+	r.cell.label.Move(fyne.NewPos(0, 0))
+	r.cell.label.Resize(fyne.NewSize(util.IntMax(labelWidth, 0), size.Height))
+}
+
+func (r *headerCellRenderer) MinSize() fyne.Size {
+	return util.InlineMinSize(r.cell.label.MinSize(), r.cell.icon.MinSize())
+}
+
+func (r *headerCellRenderer) Refresh() {
+	r.cell.label.Refresh()
+	r.cell.icon.Refresh()
+	r.Layout(r.cell.Size())
+}
+
+func (r *headerCellRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (r *headerCellRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.cell.label, r.cell.icon}
+}
+
+func (r *headerCellRenderer) Destroy() {
+}
+
+var _ fyne.Draggable = (*headerDivider)(nil)
+var _ desktop.Cursorable = (*headerDivider)(nil)
+
+// headerDivider is the draggable edge between two column headers.
+type headerDivider struct {
+	widget.BaseWidget
+
+	table  *TreeTable
+	column int
+}
+
+func newHeaderDivider(table *TreeTable, column int) *headerDivider {
+	divider := &headerDivider{
+		table:  table,
+		column: column,
+	}
+	divider.ExtendBaseWidget(divider)
+	return divider
+}
+
+// Cursor shows a resize cursor while the pointer is over the divider.
+func (d *headerDivider) Cursor() desktop.Cursor {
+	return desktop.HResizeCursor
+}
+
+// Dragged resizes the column to the left of the divider.
+func (d *headerDivider) Dragged(ev *fyne.DragEvent) {
+	columns := d.table.Columns()
+	if d.column < len(columns) {
+		d.table.SetColumnWidth(d.column, columns[d.column].Width+ev.DraggedX)
+	}
+}
+
+func (d *headerDivider) DragEnd() {
+}
+
+func (d *headerDivider) CreateRenderer() fyne.WidgetRenderer {
+	line := canvas.NewRectangle(theme.DisabledTextColor())
+	return &headerDividerRenderer{
+		divider: d,
+		line:    line,
+	}
+}
+
+type headerDividerRenderer struct {
+	divider *headerDivider
+	line    *canvas.Rectangle
+}
+
+func (r *headerDividerRenderer) Layout(size fyne.Size) {
+	r.line.Move(fyne.NewPos(size.Width/2, 0))
+	r.line.Resize(fyne.NewSize(1, size.Height))
+}
+
+func (r *headerDividerRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(headerDividerWidth, 1)
+}
+
+func (r *headerDividerRenderer) Refresh() {
+	r.line.FillColor = theme.DisabledTextColor()
+	canvas.Refresh(r.line)
+}
+
+func (r *headerDividerRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (r *headerDividerRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.line}
+}
+
+func (r *headerDividerRenderer) Destroy() {
+}
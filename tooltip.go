@@ -0,0 +1,167 @@
+package fynetree
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/layout"
+	"fyne.io/fyne/theme"
+	"fyne.io/fyne/widget"
+	"github.com/drognisep/fynetree/util"
+)
+
+// DefaultTooltipDelay is how long the pointer must rest on a row before its tooltip is shown, unless the container
+// sets its own TooltipDelay.
+const DefaultTooltipDelay = 500 * time.Millisecond
+
+// tooltip shows a node's tooltip in its container's tooltip layer after its row has been hovered for the container's
+// delay.
+type tooltip struct {
+	row     *treeRow
+	timer   *time.Timer
+	layer   *tooltipLayer
+	content fyne.CanvasObject
+	// generation is incremented whenever the tooltip is dismissed, so a timer that fires late doesn't show it.
+	generation int
+}
+
+// tooltipContent gets the content to show in the node's tooltip, or nil if it doesn't have one.
+func tooltipContent(node *TreeNode) fyne.CanvasObject {
+	switch model := node.model.(type) {
+	case TooltipContentModel:
+		return model.GetTooltipContent()
+	case TooltipModel:
+		if text := model.GetTooltip(); text != "" {
+			return widget.NewLabel(text)
+		}
+	}
+	return nil
+}
+
+// tooltipDelay gets the delay before the node's tooltip is shown.
+func tooltipDelay(node *TreeNode) time.Duration {
+	if c := node.treeContainer(); c != nil && c.TooltipDelay > 0 {
+		return c.TooltipDelay
+	}
+	return DefaultTooltipDelay
+}
+
+// schedule starts the delay before showing the tooltip for the row's node.
+func (t *tooltip) schedule() {
+	node := t.row.node
+	if node == nil || tooltipContent(node) == nil {
+		return
+	}
+	t.row.mux.Lock()
+	defer t.row.mux.Unlock()
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	generation := t.generation
+	t.timer = time.AfterFunc(tooltipDelay(node), func() {
+		t.show(node, generation)
+	})
+}
+
+// show displays the tooltip below the node's label, if the row still shows the node and hasn't been dismissed.
+func (t *tooltip) show(node *TreeNode, generation int) {
+	row := t.row
+	row.mux.Lock()
+	current := t.generation == generation && row.node == node
+	row.mux.Unlock()
+	if !current {
+		return
+	}
+	content := tooltipContent(node)
+	c := node.treeContainer()
+	app := fyne.CurrentApp()
+	if content == nil || c == nil || app == nil || app.Driver().CanvasForObject(row) == nil {
+		return
+	}
+	rowPos := app.Driver().AbsolutePositionForObject(row).Subtract(app.Driver().AbsolutePositionForObject(c))
+
+	row.mux.Lock()
+	defer row.mux.Unlock()
+	if t.generation != generation {
+		return
+	}
+	t.layer = c.tooltips
+	t.content = content
+	t.layer.show(content, rowPos.Add(fyne.NewPos(row.labelOffset(), 0)), row.Size().Height, c.Size())
+}
+
+// dismiss cancels a pending tooltip and hides a shown one.
+func (t *tooltip) dismiss() {
+	t.row.mux.Lock()
+	t.generation++
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	if t.layer != nil {
+		t.layer.hide(t.content)
+		t.layer = nil
+		t.content = nil
+	}
+	t.row.mux.Unlock()
+}
+
+// shown returns the content of the tooltip, or nil if it's not shown.
+func (t *tooltip) shown() fyne.CanvasObject {
+	t.row.mux.Lock()
+	defer t.row.mux.Unlock()
+	return t.content
+}
+
+// tooltipLayer draws the tooltip of a hovered row over a TreeContainer's rows. It's drawn by the container instead of
+// a canvas overlay, which would capture the pointer, so moving and tapping over the tooltip still reaches the row.
+type tooltipLayer struct {
+	mux        sync.Mutex
+	box        *fyne.Container
+	background *canvas.Rectangle
+	content    fyne.CanvasObject
+}
+
+func newTooltipLayer() *tooltipLayer {
+	background := canvas.NewRectangle(theme.BackgroundColor())
+	background.StrokeWidth = 1
+	box := fyne.NewContainerWithLayout(layout.NewMaxLayout(), background)
+	box.Hide()
+	return &tooltipLayer{box: box, background: background}
+}
+
+// show draws the content under the row at the position, or above it if there isn't room below, keeping it inside the
+// container's bounds.
+func (l *tooltipLayer) show(content fyne.CanvasObject, rowPos fyne.Position, rowHeight int, bounds fyne.Size) {
+	l.mux.Lock()
+	l.content = content
+	l.background.FillColor = theme.BackgroundColor()
+	l.background.StrokeColor = theme.ShadowColor()
+	l.box.Objects = []fyne.CanvasObject{l.background, fyne.NewContainerWithLayout(layout.NewPaddedLayout(), content)}
+	size := l.box.MinSize()
+	pos := fyne.NewPos(rowPos.X, rowPos.Y+rowHeight)
+	if pos.Y+size.Height > bounds.Height && rowPos.Y-size.Height >= 0 {
+		pos.Y = rowPos.Y - size.Height
+	}
+	pos.X = util.IntMax(util.IntMin(pos.X, bounds.Width-size.Width), 0)
+	l.box.Resize(size)
+	l.box.Move(pos)
+	l.box.Show()
+	l.mux.Unlock()
+	l.box.Refresh()
+}
+
+// hide stops drawing the content, unless another row's tooltip has been shown since.
+func (l *tooltipLayer) hide(content fyne.CanvasObject) {
+	l.mux.Lock()
+	if l.content != content {
+		l.mux.Unlock()
+		return
+	}
+	l.content = nil
+	l.box.Hide()
+	l.mux.Unlock()
+	l.box.Refresh()
+}
@@ -0,0 +1,108 @@
+package fynetree
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/widget"
+)
+
+type tooltipModel struct {
+	StaticNodeModel
+	tooltip string
+}
+
+func (m *tooltipModel) GetTooltip() string {
+	return m.tooltip
+}
+
+type tooltipContentModel struct {
+	StaticNodeModel
+	content fyne.CanvasObject
+}
+
+func (m *tooltipContentModel) GetTooltipContent() fyne.CanvasObject {
+	return m.content
+}
+
+func tooltipSetup(model TreeNodeModel) (*treeRow, fyne.Window) {
+	containerSetup()
+	node := NewTreeNode(model)
+	_ = treeContainer.Append(node)
+	treeContainer.TooltipDelay = 10 * time.Millisecond
+	w := showContainer()
+	return rowPart(treeContainer, node, RowPartRow).(*treeRow), w
+}
+
+func waitForTooltip(row *treeRow) fyne.CanvasObject {
+	for i := 0; i < 50; i++ {
+		if content := row.tooltip.shown(); content != nil {
+			return content
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
+
+func TestTreeRow_Tooltip(t *testing.T) {
+	row, w := tooltipSetup(&tooltipModel{StaticNodeModel{Text: "node"}, "More about the node"})
+	defer w.Close()
+
+	row.MouseIn(&desktop.MouseEvent{})
+	content := waitForTooltip(row)
+	box := treeContainer.tooltips.box
+	if content == nil || !box.Visible() {
+		t.Fatalf("Expected tooltip to be shown after the delay")
+	}
+	if label, ok := content.(*widget.Label); !ok || label.Text != "More about the node" {
+		t.Fatalf("Expected tooltip text to be shown, got %v", content)
+	}
+	if box.Position().Y < row.Size().Height {
+		t.Fatalf("Expected tooltip to be shown below the row")
+	}
+	if w.Canvas().Overlays().Top() != nil {
+		t.Fatalf("Expected tooltip not to be an overlay capturing the pointer")
+	}
+
+	row.MouseOut()
+	if row.tooltip.shown() != nil || box.Visible() {
+		t.Fatalf("Expected tooltip to be dismissed on mouse out")
+	}
+}
+
+func TestTreeRow_TooltipContent(t *testing.T) {
+	content := widget.NewIcon(nil)
+	row, w := tooltipSetup(&tooltipContentModel{StaticNodeModel{Text: "node"}, content})
+	defer w.Close()
+
+	row.MouseIn(&desktop.MouseEvent{})
+	if waitForTooltip(row) != content {
+		t.Fatalf("Expected custom tooltip content to be shown")
+	}
+	row.Tapped(&fyne.PointEvent{})
+	if row.tooltip.shown() != nil {
+		t.Fatalf("Expected tapping the row to dismiss the tooltip")
+	}
+}
+
+func TestTreeRow_TooltipCancelled(t *testing.T) {
+	row, w := tooltipSetup(&tooltipModel{StaticNodeModel{Text: "node"}, "Tooltip"})
+	defer w.Close()
+	treeContainer.TooltipDelay = 50 * time.Millisecond
+
+	row.MouseIn(&desktop.MouseEvent{})
+	row.MouseOut()
+	time.Sleep(100 * time.Millisecond)
+	if row.tooltip.shown() != nil {
+		t.Fatalf("Expected tooltip not to be shown after the mouse left")
+	}
+
+	plain, w2 := tooltipSetup(NewStaticModel(nil, "plain"))
+	defer w2.Close()
+	plain.MouseIn(&desktop.MouseEvent{})
+	if waitForTooltip(plain) != nil {
+		t.Fatalf("Expected no tooltip for a model without one")
+	}
+}
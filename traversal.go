@@ -0,0 +1,192 @@
+package fynetree
+
+import "errors"
+
+// WalkOrder determines whether Walk visits a node before or after its descendants.
+type WalkOrder int
+
+const (
+	// PreOrder visits each node before its children.
+	PreOrder WalkOrder = iota
+	// PostOrder visits each node after its children.
+	PostOrder
+)
+
+// WalkFunc is called for each node visited by a walk. Returning SkipChildren from a pre-order or breadth-first walk
+// skips the node's descendants, and returning any other error stops the walk and returns the error.
+type WalkFunc func(node *TreeNode) error
+
+// SkipChildren is returned from a WalkFunc to skip the visited node's descendants. It's ignored in a post-order walk,
+// since the descendants have already been visited.
+var SkipChildren = errors.New("skip children")
+
+// Children returns the nodes in this list.
+func (n *nodeList) Children() []*TreeNode {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	children := make([]*TreeNode, 0, len(n.Objects))
+	for _, obj := range n.Objects {
+		if node, ok := obj.(*TreeNode); ok {
+			children = append(children, node)
+		}
+	}
+	return children
+}
+
+// Walk visits this node and all of its descendants depth first, in the given order.
+func (n *TreeNode) Walk(order WalkOrder, fn WalkFunc) error {
+	return walkNodes([]*TreeNode{n}, order, fn)
+}
+
+// WalkBreadthFirst visits this node and all of its descendants one level at a time.
+func (n *TreeNode) WalkBreadthFirst(fn WalkFunc) error {
+	return walkBreadthFirst([]*TreeNode{n}, fn)
+}
+
+// FindFirst returns the first node in pre-order, starting with this node, that matches the predicate, or nil if none do.
+func (n *TreeNode) FindFirst(predicate NodeFilter) *TreeNode {
+	return findFirst([]*TreeNode{n}, predicate)
+}
+
+// FindAll returns every node in pre-order, starting with this node, that matches the predicate.
+func (n *TreeNode) FindAll(predicate NodeFilter) []*TreeNode {
+	return findAll([]*TreeNode{n}, predicate)
+}
+
+// Depth returns how many ancestors this node has. Root nodes have a depth of 0.
+func (n *TreeNode) Depth() int {
+	var depth int
+	for p := n.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
+// Root returns the topmost ancestor of this node, or the node itself if it has no parent.
+func (n *TreeNode) Root() *TreeNode {
+	root := n
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// Ancestors returns this node's parent, its parent's parent, and so on up to the root.
+func (n *TreeNode) Ancestors() []*TreeNode {
+	var ancestors []*TreeNode
+	for p := n.parent; p != nil; p = p.parent {
+		ancestors = append(ancestors, p)
+	}
+	return ancestors
+}
+
+// NextSibling returns the node after this one in its parent or TreeContainer, or nil if it's the last one.
+func (n *TreeNode) NextSibling() *TreeNode {
+	return n.sibling(1)
+}
+
+// PrevSibling returns the node before this one in its parent or TreeContainer, or nil if it's the first one.
+func (n *TreeNode) PrevSibling() *TreeNode {
+	return n.sibling(-1)
+}
+
+func (n *TreeNode) sibling(delta int) *TreeNode {
+	var list *nodeList
+	if n.parent != nil {
+		list = n.parent.nodeList
+	} else if n.container != nil {
+		list = n.container.nodeList
+	} else {
+		return nil
+	}
+	siblings := list.Children()
+	i := indexOfNode(siblings, n)
+	if i < 0 || i+delta < 0 || i+delta >= len(siblings) {
+		return nil
+	}
+	return siblings[i+delta]
+}
+
+// Walk visits every node in the container depth first, in the given order.
+func (t *TreeContainer) Walk(order WalkOrder, fn WalkFunc) error {
+	return walkNodes(t.Children(), order, fn)
+}
+
+// WalkBreadthFirst visits every node in the container one level at a time.
+func (t *TreeContainer) WalkBreadthFirst(fn WalkFunc) error {
+	return walkBreadthFirst(t.Children(), fn)
+}
+
+// FindFirst returns the first node in pre-order that matches the predicate, or nil if none do.
+func (t *TreeContainer) FindFirst(predicate NodeFilter) *TreeNode {
+	return findFirst(t.Children(), predicate)
+}
+
+// FindAll returns every node in pre-order that matches the predicate.
+func (t *TreeContainer) FindAll(predicate NodeFilter) []*TreeNode {
+	return findAll(t.Children(), predicate)
+}
+
+func walkNodes(nodes []*TreeNode, order WalkOrder, fn WalkFunc) error {
+	for _, node := range nodes {
+		if order == PreOrder {
+			err := fn(node)
+			if err == SkipChildren {
+				continue
+			} else if err != nil {
+				return err
+			}
+		}
+		if err := walkNodes(node.Children(), order, fn); err != nil {
+			return err
+		}
+		if order == PostOrder {
+			if err := fn(node); err != nil && err != SkipChildren {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func walkBreadthFirst(nodes []*TreeNode, fn WalkFunc) error {
+	queue := nodes
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		err := fn(node)
+		if err == SkipChildren {
+			continue
+		} else if err != nil {
+			return err
+		}
+		queue = append(queue, node.Children()...)
+	}
+	return nil
+}
+
+// errFound stops a walk once a search has found what it's looking for.
+var errFound = errors.New("found")
+
+func findFirst(nodes []*TreeNode, predicate NodeFilter) *TreeNode {
+	var found *TreeNode
+	_ = walkNodes(nodes, PreOrder, func(node *TreeNode) error {
+		if predicate(node) {
+			found = node
+			return errFound
+		}
+		return nil
+	})
+	return found
+}
+
+func findAll(nodes []*TreeNode, predicate NodeFilter) []*TreeNode {
+	var found []*TreeNode
+	_ = walkNodes(nodes, PreOrder, func(node *TreeNode) error {
+		if predicate(node) {
+			found = append(found, node)
+		}
+		return nil
+	})
+	return found
+}
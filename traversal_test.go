@@ -0,0 +1,110 @@
+package fynetree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// traversalSetup builds root -> (A -> (C, D), B) in a container.
+func traversalSetup() {
+	containerSetup()
+	_ = treeContainer.Append(rootNode)
+	_ = rootNode.Append(nodeA)
+	_ = rootNode.Append(nodeB)
+	_ = nodeA.Append(nodeC)
+	_ = nodeA.Append(nodeD)
+}
+
+func walkText(t *testing.T, walk func(fn WalkFunc) error, skip *TreeNode) string {
+	t.Helper()
+	var visited []string
+	err := walk(func(node *TreeNode) error {
+		visited = append(visited, node.GetModelText())
+		if node == skip {
+			return SkipChildren
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected walk error: %v", err)
+	}
+	return strings.Join(visited, ",")
+}
+
+func TestTreeNode_Walk(t *testing.T) {
+	traversalSetup()
+	tests := map[string]struct {
+		walk func(fn WalkFunc) error
+		skip *TreeNode
+		want string
+	}{
+		"Pre-order":          {walk: func(fn WalkFunc) error { return rootNode.Walk(PreOrder, fn) }, want: "root,A,C,D,B"},
+		"Post-order":         {walk: func(fn WalkFunc) error { return rootNode.Walk(PostOrder, fn) }, want: "C,D,A,B,root"},
+		"Breadth-first":      {walk: rootNode.WalkBreadthFirst, want: "root,A,B,C,D"},
+		"Skip pre-order":     {walk: func(fn WalkFunc) error { return rootNode.Walk(PreOrder, fn) }, skip: nodeA, want: "root,A,B"},
+		"Skip breadth-first": {walk: rootNode.WalkBreadthFirst, skip: nodeA, want: "root,A,B"},
+		"Skip post-order":    {walk: func(fn WalkFunc) error { return rootNode.Walk(PostOrder, fn) }, skip: nodeA, want: "C,D,A,B,root"},
+		"Container":          {walk: func(fn WalkFunc) error { return treeContainer.Walk(PreOrder, fn) }, want: "root,A,C,D,B"},
+	}
+	for name, tc := range tests {
+		if got := walkText(t, tc.walk, tc.skip); got != tc.want {
+			t.Fatalf("%s: expected %s, got %s", name, tc.want, got)
+		}
+	}
+}
+
+func TestTreeNode_WalkStopsOnError(t *testing.T) {
+	traversalSetup()
+	stop := errors.New("stop")
+	var visited int
+	err := rootNode.Walk(PreOrder, func(node *TreeNode) error {
+		visited++
+		if node == nodeC {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || visited != 3 {
+		t.Fatalf("Expected walk to stop at C after 3 nodes, visited %d with error %v", visited, err)
+	}
+}
+
+func TestTreeNode_Find(t *testing.T) {
+	traversalSetup()
+	isLeaf := func(node *TreeNode) bool { return node.NumChildren() == 0 }
+	if found := treeContainer.FindFirst(isLeaf); found != nodeC {
+		t.Fatalf("Expected to find C first")
+	}
+	if found := nodeA.FindAll(isLeaf); len(found) != 2 || found[0] != nodeC || found[1] != nodeD {
+		t.Fatalf("Expected to find C and D under A")
+	}
+	if found := rootNode.FindFirst(SubstringFilter("missing")); found != nil {
+		t.Fatalf("Expected nothing to be found")
+	}
+}
+
+func TestTreeNode_Relatives(t *testing.T) {
+	traversalSetup()
+	if nodeD.Depth() != 2 || rootNode.Depth() != 0 {
+		t.Fatalf("Unexpected node depth")
+	}
+	if nodeD.Root() != rootNode || rootNode.Root() != rootNode {
+		t.Fatalf("Unexpected root node")
+	}
+	if ancestors := nodeD.Ancestors(); len(ancestors) != 2 || ancestors[0] != nodeA || ancestors[1] != rootNode {
+		t.Fatalf("Expected ancestors to be A then root")
+	}
+	if nodeC.NextSibling() != nodeD || nodeD.NextSibling() != nil {
+		t.Fatalf("Unexpected next sibling")
+	}
+	if nodeD.PrevSibling() != nodeC || nodeC.PrevSibling() != nil {
+		t.Fatalf("Unexpected previous sibling")
+	}
+	if children := rootNode.Children(); len(children) != 2 || children[0] != nodeA || children[1] != nodeB {
+		t.Fatalf("Expected children to be A and B")
+	}
+	if roots := treeContainer.Children(); len(roots) != 1 || roots[0] != rootNode {
+		t.Fatalf("Expected container children to be the root")
+	}
+}
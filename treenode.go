@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
 	"fyne.io/fyne/widget"
 )
 
@@ -27,9 +28,20 @@ type TreeNode struct {
 	OnLabelTapped     TapEventHandler
 	OnTapped          TapEventHandler
 	OnDoubleTapped    TapEventHandler
+	OnActivated       NodeEventHandler
 
-	mux    sync.Mutex
-	parent *TreeNode
+	mux            sync.Mutex
+	observing      bool
+	parent         *TreeNode
+	container      *TreeContainer
+	tapModifier    desktop.Modifier
+	loadState      loadState
+	loadGeneration int
+	loadMux        sync.Mutex
+	checkState     CheckState
+	style          *TreeStyle
+	placeholder    bool
+	rendered       bool
 }
 
 // NewTreeNode constructs a tree node with the given model.
@@ -54,14 +66,19 @@ func InitTreeNode(newNode *TreeNode, model TreeNodeModel) {
 	if newNode == nil {
 		newNode = &TreeNode{}
 	}
+	newNode.unobserveModel()
 	newNode.model = model
 	newNode.initNodeListEvents()
 	newNode.OnBeforeExpand = func() {}
 	newNode.OnAfterCondense = func() {}
 	newNode.OnTappedSecondary = func(pe *fyne.PointEvent) {}
 	newNode.leaf = false
+	if provider, ok := model.(ChildrenProvider); ok {
+		newNode.leaf = !provider.HasChildren()
+	}
 	model.SetTreeNode(newNode)
 	newNode.ExtendBaseWidget(newNode)
+	newNode.observeModel()
 }
 
 func (n *TreeNode) initNodeListEvents() {
@@ -72,29 +89,73 @@ func (n *TreeNode) initNodeListEvents() {
 			}
 			if i, ok := item.(*TreeNode); ok {
 				i.parent = n
+				n.visibilityChanged()
+				i.setObserving(true)
+				n.childAdded(i)
 				n.Refresh()
+				if c := n.treeContainer(); c != nil {
+					c.restoreExpansion(i)
+				}
 			}
 		},
 		OnAfterRemoval: func(item fyne.CanvasObject) {
 			if item != nil {
 				if i, ok := item.(*TreeNode); ok {
 					i.parent = nil
+					n.visibilityChanged()
+					i.setObserving(false)
+					n.checkChanged(n.updateCheckState())
+					if c := n.treeContainer(); c != nil {
+						c.nodeRemoved(i)
+					}
 					n.Refresh()
 				}
 			}
 		},
+		history: n.history,
 	}
 }
 
+// TappedSecondary calls the OnTappedSecondary hook, then shows the node's context menu if its container has one for it.
 func (n *TreeNode) TappedSecondary(pe *fyne.PointEvent) {
 	if n.OnTappedSecondary != nil {
 		n.OnTappedSecondary(pe)
 	}
+	if c := n.treeContainer(); c != nil {
+		c.showContextMenu(n, pe)
+	}
 }
 
+// DoubleTapped calls the OnDoubleTapped hook, or starts renaming the node if there's no hook and its container allows
+// inline renaming.
 func (n *TreeNode) DoubleTapped(pe *fyne.PointEvent) {
 	if n.OnDoubleTapped != nil {
 		n.OnDoubleTapped(pe)
+	} else if c := n.treeContainer(); c != nil && c.InlineRename {
+		_ = c.StartRename(n)
+	}
+}
+
+// tapped is called by the node's view components when they're tapped, and updates the container's selection.
+func (n *TreeNode) tapped(pe *fyne.PointEvent) {
+	modifier := n.tapModifier
+	n.tapModifier = 0
+	if n.OnTapped != nil {
+		n.OnTapped(pe)
+	}
+	if c := n.treeContainer(); c != nil {
+		c.setCursor(n)
+		c.requestFocus()
+		c.nodeTapped(n, modifier)
+	}
+}
+
+// Activate triggers the OnActivated hook, or toggles the expand state of a branch node if no hook is set.
+func (n *TreeNode) Activate() {
+	if n.OnActivated != nil {
+		n.OnActivated()
+	} else if n.IsBranch() {
+		n.ToggleExpand()
 	}
 }
 
@@ -102,11 +163,68 @@ func (n *TreeNode) CreateRenderer() fyne.WidgetRenderer {
 	return newTreeEntryRenderer(n)
 }
 
+// Refresh updates this node's row in its TreeContainer, or its own view if it's being shown outside of a container.
+// Nodes in a TreeContainer don't create their own renderer, so large trees only pay for the rows that are visible.
+func (n *TreeNode) Refresh() {
+	if c := n.treeContainer(); c != nil {
+		c.Refresh()
+	} else if n.rendered {
+		n.BaseWidget.Refresh()
+	}
+}
+
+// batch calls fn in a Batch of the node's container, so the container is only refreshed once after fn returns.
+func (n *TreeNode) batch(fn func()) {
+	if c := n.treeContainer(); c != nil {
+		c.Batch(fn)
+	} else {
+		fn()
+	}
+}
+
+// visibilityChanged forgets the container's visible rows after this node's children are added, removed, shown or hidden.
+func (n *TreeNode) visibilityChanged() {
+	if c := n.treeContainer(); c != nil {
+		c.invalidateVisible()
+	}
+}
+
 // GetParent gets the parent node, or nil if this is a root node.
 func (n *TreeNode) GetParent() *TreeNode {
 	return n.parent
 }
 
+// treeContainer gets the TreeContainer holding this node's root, or nil if it's not in a container.
+func (n *TreeNode) treeContainer() *TreeContainer {
+	return n.Root().container
+}
+
+// isDescendantOf returns whether the given node is an ancestor of this node.
+func (n *TreeNode) isDescendantOf(ancestor *TreeNode) bool {
+	for p := n.parent; p != nil; p = p.parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSelected returns whether this node is selected in its TreeContainer.
+func (n *TreeNode) IsSelected() bool {
+	if c := n.treeContainer(); c != nil {
+		return c.IsSelected(n)
+	}
+	return false
+}
+
+// HasFocus returns whether this node has the keyboard cursor in a focused TreeContainer.
+func (n *TreeNode) HasFocus() bool {
+	if c := n.treeContainer(); c != nil {
+		return c.Focused() && c.FocusedNode() == n
+	}
+	return false
+}
+
 // NumChildren returns how many child nodes this node has.
 func (n *TreeNode) NumChildren() int {
 	n.mux.Lock()
@@ -158,14 +276,25 @@ func (n *TreeNode) IsCondensed() bool {
 }
 
 // Expand expands the node and triggers the OnBeforeExpand hook in the model if it's a branch and not already expanded.
+// Children of a ChildrenProvider model are loaded in the background the first time it's expanded.
 func (n *TreeNode) Expand() {
 	if n.IsBranch() && n.IsCondensed() {
-		if n.OnBeforeExpand != nil {
-			n.OnBeforeExpand()
+		var provider ChildrenProvider
+		var generation int
+		n.batch(func() {
+			if n.OnBeforeExpand != nil {
+				n.OnBeforeExpand()
+			}
+			provider, generation = n.prepareLoad()
+			n.showChildren()
+			n.expanded = true
+			n.visibilityChanged()
+			n.expandedChanged()
+			n.Refresh()
+		})
+		if provider != nil {
+			go n.loadChildren(provider, generation)
 		}
-		n.showChildren()
-		n.expanded = true
-		n.Refresh()
 	}
 }
 
@@ -176,23 +305,76 @@ func (n *TreeNode) showChildren() {
 }
 
 // Condense condenses the node and triggers the AfterCondense hook in the model if it's a branch and not already condensed.
+// Children still being loaded are discarded, and loaded again the next time it's expanded.
 func (n *TreeNode) Condense() {
 	if n.IsBranch() && n.IsExpanded() {
-		n.expanded = false
-		n.hideChildren()
-		n.Refresh()
+		n.cancelLoad()
+		n.batch(func() {
+			n.expanded = false
+			n.visibilityChanged()
+			n.hideChildren()
+			n.expandedChanged()
+			if c := n.treeContainer(); c != nil {
+				c.nodeCondensed(n)
+			}
+			n.Refresh()
+		})
 		if n.OnAfterCondense != nil {
 			n.OnAfterCondense()
 		}
 	}
 }
 
+// expandedChanged records the node being expanded or condensed in its container's history, and updates the
+// container's remembered expansion state.
+func (n *TreeNode) expandedChanged() {
+	if c := n.treeContainer(); c != nil {
+		c.expansionChanged(n)
+		if !c.isRestoring() {
+			c.record(&expandCommand{node: n, expanded: n.expanded})
+		}
+	}
+}
+
+// appendVisible appends this node and any of its shown descendants to the given slice in display order.
+func (n *TreeNode) appendVisible(visible []*TreeNode) []*TreeNode {
+	visible = append(visible, n)
+	if n.IsBranch() && n.IsExpanded() {
+		for _, obj := range n.nodeList.Objects {
+			if child, ok := obj.(*TreeNode); ok {
+				visible = child.appendVisible(visible)
+			}
+		}
+	}
+	return visible
+}
+
 func (n *TreeNode) hideChildren() {
 	for _, c := range n.nodeList.Objects {
 		c.Hide()
 	}
 }
 
+// ExpandAll expands this node and all of its descendants.
+func (n *TreeNode) ExpandAll() {
+	n.batch(func() {
+		_ = n.Walk(PreOrder, func(node *TreeNode) error {
+			node.Expand()
+			return nil
+		})
+	})
+}
+
+// CondenseAll condenses this node and all of its descendants.
+func (n *TreeNode) CondenseAll() {
+	n.batch(func() {
+		_ = n.Walk(PostOrder, func(node *TreeNode) error {
+			node.Condense()
+			return nil
+		})
+	})
+}
+
 // ToggleExpand toggles the expand state of the node.
 func (n *TreeNode) ToggleExpand() {
 	if n.expanded {
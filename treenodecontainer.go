@@ -3,33 +3,78 @@ package fynetree
 import (
 	"image/color"
 	"sync"
+	"time"
 
 	"fyne.io/fyne"
 	"fyne.io/fyne/container"
-	"fyne.io/fyne/theme"
+	"fyne.io/fyne/driver/desktop"
 	"fyne.io/fyne/widget"
 )
 
 var _ fyne.Widget = (*TreeContainer)(nil)
+var _ desktop.Keyable = (*TreeContainer)(nil)
+var _ fyne.Shortcutable = (*TreeContainer)(nil)
 
 // TreeContainer widget simplifies display of several root tree nodes.
+// The container scrolls its own content, and only creates views for the rows that are scrolled into view, so it
+// shouldn't be placed in another scroll container.
 type TreeContainer struct {
 	widget.BaseWidget
 	*nodeList
-	Background color.Color
+	Background         color.Color
+	SelectionMode      SelectionMode
+	OnSelectionChanged SelectionChangedHandler
+	DragAndDrop        bool
+	OnBeforeDrop       DropVetoHandler
+	OnAfterDrop        DropHandler
+	Checkboxes         bool
+	OnCheckChanged     CheckChangedHandler
+	InlineRename       bool
+	OnRenamed          RenameHandler
+	OnRenameError      RenameErrorHandler
+	History            *History
+	Style              TreeStyle
+	TooltipDelay       time.Duration
+	MenuProvider       MenuProvider
+	MenuActions        MenuActions
 
-	mux           sync.Mutex
-	vboxContainer *fyne.Container
+	mux               sync.Mutex
+	viewport          *treeViewport
+	tooltips          *tooltipLayer
+	selected          []*TreeNode
+	anchor            *TreeNode
+	cursor            *TreeNode
+	focused           bool
+	shiftHeld         bool
+	dragging          *TreeNode
+	dropTarget        *TreeNode
+	dropPosition      DropPosition
+	filter            NodeFilter
+	filterExpansion   map[*TreeNode]bool
+	renaming          *TreeNode
+	lastTapped        *TreeNode
+	lastTapTime       time.Time
+	table             *TreeTable
+	expansion         ExpansionState
+	expansionPrefs    fyne.Preferences
+	expansionPrefsKey string
+	restoring         bool
+	visible           []*TreeNode
+	shown             map[*TreeNode]bool
+	visibleValid      bool
+	visibleGen        int
+	batchDepth        int
+	refreshPending    bool
 }
 
 func NewTreeContainer() *TreeContainer {
-	var baseRoots []fyne.CanvasObject
-	vboxContainer := container.NewVBox(baseRoots...)
 	c := &TreeContainer{
 		Background:    color.Transparent,
-		vboxContainer: vboxContainer,
+		SelectionMode: SelectionSingle,
 	}
 	c.ExtendBaseWidget(c)
+	c.viewport = newTreeViewport(c)
+	c.tooltips = newTooltipLayer()
 	c.nodeList = &nodeList{
 		OnAfterAddition: func(item fyne.CanvasObject) {
 			if item == nil {
@@ -37,18 +82,29 @@ func NewTreeContainer() *TreeContainer {
 			}
 			if i, ok := item.(*TreeNode); ok {
 				i.parent = nil
+				i.container = c
+				c.invalidateVisible()
+				i.setObserving(true)
 				c.Refresh()
+				c.restoreExpansion(i)
 			}
 		},
 		OnAfterRemoval: func(item fyne.CanvasObject) {
 			if item != nil {
 				if i, ok := item.(*TreeNode); ok {
 					i.parent = nil
+					i.container = nil
+					c.invalidateVisible()
+					i.setObserving(false)
+					c.nodeRemoved(i)
 					c.Refresh()
 				}
 			}
 		},
 	}
+	c.nodeList.history = func() *History {
+		return c.History
+	}
 
 	return c
 }
@@ -59,9 +115,108 @@ func (t *TreeContainer) NumRoots() int {
 	return t.Len()
 }
 
+// nodeRemoved clears any container state referencing the removed node or its descendants.
+func (t *TreeContainer) nodeRemoved(removed *TreeNode) {
+	t.pruneSelection(removed)
+	t.pruneCursor(removed)
+	t.pruneRename(removed)
+	t.pruneDrag(removed)
+}
+
+// visibleNodes returns every node that would currently be shown as a row, in display order.
+// The list is cached until the container is next refreshed, so it must not be modified.
+func (t *TreeContainer) visibleNodes() []*TreeNode {
+	visible, _ := t.visibleState()
+	return visible
+}
+
+// filterShown returns the set of nodes shown as rows while a filter is applied, or nil if there's no filter and every
+// expanded node is shown. It's cached with the visible rows, so it must not be modified.
+func (t *TreeContainer) filterShown() map[*TreeNode]bool {
+	_, shown := t.visibleState()
+	return shown
+}
+
+func (t *TreeContainer) visibleState() ([]*TreeNode, map[*TreeNode]bool) {
+	t.mux.Lock()
+	if t.visibleValid {
+		visible, shown := t.visible, t.shown
+		t.mux.Unlock()
+		return visible, shown
+	}
+	gen := t.visibleGen
+	filter := t.filter
+	t.mux.Unlock()
+
+	var visible []*TreeNode
+	var shown map[*TreeNode]bool
+	for _, node := range t.Children() {
+		if filter != nil {
+			visible, _ = node.appendFiltered(visible, filter)
+		} else {
+			visible = node.appendVisible(visible)
+		}
+	}
+	if filter != nil {
+		shown = make(map[*TreeNode]bool, len(visible))
+		for _, node := range visible {
+			shown[node] = true
+		}
+	}
+
+	t.mux.Lock()
+	if t.visibleGen == gen {
+		t.visible = visible
+		t.shown = shown
+		t.visibleValid = true
+	}
+	t.mux.Unlock()
+	return visible, shown
+}
+
+// invalidateVisible forgets the cached visible rows, so they're listed again the next time they're needed.
+func (t *TreeContainer) invalidateVisible() {
+	t.mux.Lock()
+	t.visible = nil
+	t.shown = nil
+	t.visibleValid = false
+	t.visibleGen++
+	t.mux.Unlock()
+}
+
+// Refresh redraws the container after nodes are added, removed, expanded, condensed or filtered.
+// Inside a Batch the redraw is deferred until the batch ends.
 func (t *TreeContainer) Refresh() {
-	t.vboxContainer.Objects = t.nodeList.Objects
-	t.vboxContainer.Refresh()
+	t.invalidateVisible()
+	t.mux.Lock()
+	if t.batchDepth > 0 {
+		t.refreshPending = true
+		t.mux.Unlock()
+		return
+	}
+	t.mux.Unlock()
+	t.BaseWidget.Refresh()
+}
+
+// Batch calls fn, deferring any refreshes until it returns so bulk changes like appending many nodes are only redrawn
+// once. Batches may be nested.
+func (t *TreeContainer) Batch(fn func()) {
+	t.mux.Lock()
+	t.batchDepth++
+	t.mux.Unlock()
+	defer func() {
+		t.mux.Lock()
+		t.batchDepth--
+		refresh := t.batchDepth == 0 && t.refreshPending
+		if refresh {
+			t.refreshPending = false
+		}
+		t.mux.Unlock()
+		if refresh {
+			t.BaseWidget.Refresh()
+		}
+	}()
+	fn()
 }
 
 func (t *TreeContainer) CreateRenderer() fyne.WidgetRenderer {
@@ -72,19 +227,20 @@ var _ fyne.WidgetRenderer = (*treeContainerRenderer)(nil)
 
 type treeContainerRenderer struct {
 	scrollContainer *container.Scroll
+	tooltips        *fyne.Container
 	treeContainer   *TreeContainer
 }
 
 func newTreeContainerRenderer(treeContainer *TreeContainer) *treeContainerRenderer {
-	t := &treeContainerRenderer{
-		treeContainer: treeContainer,
+	return &treeContainerRenderer{
+		treeContainer:   treeContainer,
+		scrollContainer: treeContainer.viewport.scroll,
+		tooltips:        treeContainer.tooltips.box,
 	}
-	t.scrollContainer = container.NewScroll(container.NewVBox(t.treeContainer.Objects...))
-	return t
 }
 
 func (t *treeContainerRenderer) BackgroundColor() color.Color {
-	return color.Transparent
+	return t.treeContainer.Background
 }
 
 func (t *treeContainerRenderer) Destroy() {
@@ -92,28 +248,19 @@ func (t *treeContainerRenderer) Destroy() {
 	t.treeContainer = nil
 }
 
-func (t *treeContainerRenderer) Layout(_ fyne.Size) {
-	y := theme.Padding()
-	for _, i := range t.Objects() {
-		iSize := i.MinSize()
-		i.Resize(iSize)
-		i.Move(fyne.NewPos(theme.Padding(), y))
-		y = iSize.Height + y
-	}
+func (t *treeContainerRenderer) Layout(size fyne.Size) {
+	t.scrollContainer.Resize(size)
 }
 
 func (t *treeContainerRenderer) MinSize() fyne.Size {
-	runningSize := fyne.NewSize(0, 0)
-	for _, i := range t.Objects() {
-		iSize := i.MinSize()
-		runningSize = fyne.NewSize(runningSize.Max(iSize).Width, runningSize.Height+iSize.Height)
-	}
-	return runningSize
+	return t.scrollContainer.MinSize()
 }
 
 func (t *treeContainerRenderer) Objects() []fyne.CanvasObject {
-	return t.treeContainer.Objects
+	return []fyne.CanvasObject{t.scrollContainer, t.tooltips}
 }
 
 func (t *treeContainerRenderer) Refresh() {
+	t.scrollContainer.Refresh()
+	t.treeContainer.viewport.Refresh()
 }
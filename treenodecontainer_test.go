@@ -1,6 +1,11 @@
 package fynetree
 
-import "testing"
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+)
 
 var treeContainer *TreeContainer
 
@@ -9,6 +14,14 @@ func containerSetup() {
 	treeContainer = NewTreeContainer()
 }
 
+// showContainer shows treeContainer in a test window, for tests of how its rows are drawn.
+func showContainer() fyne.Window {
+	test.NewApp()
+	w := test.NewWindow(treeContainer)
+	w.Resize(fyne.NewSize(300, 300))
+	return w
+}
+
 func TestTreeContainer_AddRemove(t *testing.T) {
 	containerSetup()
 	if nodeA.parent != nil {
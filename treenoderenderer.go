@@ -8,61 +8,47 @@ import (
 )
 
 const (
+	// HierarchyPadding is the default indent for each level of the tree.
 	HierarchyPadding = 24
 )
 
+// treeEntryRenderer is used when a TreeNode is shown on its own rather than in a TreeContainer, and nests the node's
+// children below its own row.
 type treeEntryRenderer struct {
 	node   *TreeNode
-	handle *expandHandle
-	icon   *nodeIcon
-	label  *nodeLabel
+	row    *treeRow
+	guides *guideLines
 }
 
 func newTreeEntryRenderer(node *TreeNode) fyne.WidgetRenderer {
-	handle := NewExpandHandle(node)
-	icon := newNodeIcon(node, node.GetModelIconResource())
-	label := newNodeLabel(node, node.GetModelText())
+	node.rendered = true
 	return &treeEntryRenderer{
 		node:   node,
-		handle: handle,
-		icon:   icon,
-		label:  label,
+		row:    newTreeRow(node, 0),
+		guides: &guideLines{},
 	}
 }
 
 func (renderer treeEntryRenderer) Layout(container fyne.Size) {
 	node := renderer.node
-	itemsHeight := renderer.entryItemsMinSize().Height
-	handle := renderer.handle
-	handleSize := handle.MinSize()
-	handleWidth := handleSize.Width
-	handle.Move(fyne.NewPos(0, 0))
-	handle.Resize(fyne.NewSize(handleWidth, itemsHeight))
-	icon := renderer.icon
-	iconSize := icon.MinSize()
-	var iconWidth int
-	if icon.Resource != nil {
-		iconWidth = iconSize.Width
-		icon.Move(fyne.NewPos(handleWidth, 0))
-		icon.Resize(fyne.NewSize(iconWidth, itemsHeight))
-	} else {
-		iconWidth = 0
-	}
-	label := renderer.label
-	if label.Text != "" {
-		label.Move(fyne.NewPos(handleWidth+iconWidth, 0))
-		label.Resize(fyne.NewSize(label.MinSize().Width, itemsHeight))
-	}
+	rowHeight := renderer.row.MinSize().Height
+	renderer.row.Move(fyne.NewPos(0, 0))
+	renderer.row.Resize(fyne.NewSize(container.Width, rowHeight))
 	if node.IsBranch() && node.IsExpanded() {
-		var runningY = itemsHeight
+		style := nodeStyle(node)
+		var runningY = rowHeight
+		var childTops []int
 		for _, c := range node.nodeList.Objects {
 			cSize := c.MinSize()
-			c.Move(fyne.NewPos(HierarchyPadding, runningY))
-			c.Resize(fyne.NewSize(container.Width-HierarchyPadding, cSize.Height))
+			c.Move(fyne.NewPos(style.Indent, runningY))
+			c.Resize(fyne.NewSize(container.Width-style.Indent, cSize.Height))
+			childTops = append(childTops, runningY)
 			runningY += cSize.Height
 			c.Show()
 		}
+		renderer.guides.layout(childGuides(style, rowHeight, childTops))
 	} else {
+		renderer.guides.layout(nil)
 		for _, c := range node.nodeList.Objects {
 			c.Hide()
 		}
@@ -70,7 +56,7 @@ func (renderer treeEntryRenderer) Layout(container fyne.Size) {
 }
 
 func (renderer treeEntryRenderer) MinSize() fyne.Size {
-	entryItemsSize := renderer.entryItemsMinSize()
+	rowSize := renderer.row.MinSize()
 	var childrenSize fyne.Size
 	for _, c := range renderer.node.nodeList.Objects {
 		if c.Visible() {
@@ -81,40 +67,13 @@ func (renderer treeEntryRenderer) MinSize() fyne.Size {
 			}
 		}
 	}
-	return fyne.NewSize(util.IntMax(entryItemsSize.Width, childrenSize.Width+HierarchyPadding), entryItemsSize.Height+childrenSize.Height)
-}
-
-func (renderer treeEntryRenderer) entryItemsMinSize() fyne.Size {
-	handleSize := renderer.handle.MinSize()
-	iconSize := renderer.icon.MinSize()
-	labelSize := renderer.label.MinSize()
-	return util.InlineMinSize(handleSize, iconSize, labelSize)
+	indent := nodeStyle(renderer.node).Indent
+	return fyne.NewSize(util.IntMax(rowSize.Width, childrenSize.Width+indent), rowSize.Height+childrenSize.Height)
 }
 
 func (renderer treeEntryRenderer) Refresh() {
-	renderer.updateItemBoxState()
-}
-
-func (renderer *treeEntryRenderer) updateItemBoxState() {
-	node := renderer.node
-
-	renderer.handle.Refresh()
-	// Update icon and label from view model
-	iconResource := node.GetModelIconResource()
-	labelText := node.GetModelText()
-
-	renderer.icon.SetResource(iconResource)
-	if iconResource == nil {
-		renderer.icon.Hide()
-	} else {
-		renderer.icon.Show()
-	}
-	renderer.label.SetText(labelText)
-	if labelText == "" {
-		renderer.label.Hide()
-	} else {
-		renderer.icon.Show()
-	}
+	renderer.Layout(renderer.node.Size())
+	renderer.row.Refresh()
 }
 
 func (renderer treeEntryRenderer) BackgroundColor() color.Color {
@@ -122,16 +81,13 @@ func (renderer treeEntryRenderer) BackgroundColor() color.Color {
 }
 
 func (renderer *treeEntryRenderer) Objects() []fyne.CanvasObject {
-	return append([]fyne.CanvasObject{renderer.handle, renderer.icon, renderer.label}, renderer.node.nodeList.Objects...)
+	objects := append(renderer.guides.objects(), renderer.row)
+	return append(objects, renderer.node.nodeList.Objects...)
 }
 
 func (renderer *treeEntryRenderer) Destroy() {
-	renderer.handle.node = nil
-	renderer.handle = nil
-	renderer.icon.node = nil
-	renderer.icon = nil
-	renderer.label.node = nil
-	renderer.label = nil
+	renderer.node.rendered = false
+	renderer.row = nil
 	renderer.node = nil
 }
 
@@ -0,0 +1,356 @@
+package fynetree
+
+import (
+	"image/color"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/theme"
+	"fyne.io/fyne/widget"
+	"github.com/drognisep/fynetree/util"
+)
+
+var _ fyne.Widget = (*treeRow)(nil)
+var _ fyne.Tappable = (*treeRow)(nil)
+var _ fyne.DoubleTappable = (*treeRow)(nil)
+var _ fyne.SecondaryTappable = (*treeRow)(nil)
+var _ desktop.Hoverable = (*treeRow)(nil)
+var _ desktop.Mouseable = (*treeRow)(nil)
+
+// treeRow displays the handle, icon and label of a single TreeNode, without any of its children.
+// Rows can be bound to a different node at any time, which allows them to be recycled as the tree is scrolled.
+// A row spans the full width of its container, so taps and hovers on the space around the label reach the node.
+type treeRow struct {
+	widget.BaseWidget
+
+	node     *TreeNode
+	depth    int
+	tree     *TreeContainer
+	hovered  bool
+	renderer *treeRowRenderer
+
+	mux     sync.Mutex
+	tooltip tooltip
+}
+
+func newTreeRow(node *TreeNode, depth int) *treeRow {
+	if node == nil {
+		panic("Can't pass nil node to treeRow")
+	}
+	row := &treeRow{
+		node:  node,
+		depth: depth,
+	}
+	row.tooltip.row = row
+	row.ExtendBaseWidget(row)
+	return row
+}
+
+// bind displays the given node in this row, indented to the given depth.
+func (r *treeRow) bind(node *TreeNode, depth int) {
+	if r.node != node {
+		r.tooltip.dismiss()
+	}
+	r.mux.Lock()
+	r.node = node
+	r.depth = depth
+	r.mux.Unlock()
+	r.Refresh()
+}
+
+// boundNode returns the node this row displays, and the depth it's indented to.
+func (r *treeRow) boundNode() (*TreeNode, int) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.node, r.depth
+}
+
+// Tapped selects the row's node, like tapping its label or icon.
+func (r *treeRow) Tapped(pe *fyne.PointEvent) {
+	r.tooltip.dismiss()
+	r.node.tapped(pe)
+}
+
+func (r *treeRow) DoubleTapped(pe *fyne.PointEvent) {
+	r.node.DoubleTapped(pe)
+}
+
+func (r *treeRow) TappedSecondary(pe *fyne.PointEvent) {
+	r.node.TappedSecondary(pe)
+}
+
+// MouseDown records held modifier keys so the following tap can extend the selection.
+func (r *treeRow) MouseDown(me *desktop.MouseEvent) {
+	r.node.tapModifier = me.Modifier
+}
+
+func (r *treeRow) MouseUp(_ *desktop.MouseEvent) {
+}
+
+// MouseIn highlights the row while the pointer is over it, and shows the node's tooltip after a delay.
+func (r *treeRow) MouseIn(_ *desktop.MouseEvent) {
+	r.hovered = true
+	r.Refresh()
+	r.tooltip.schedule()
+}
+
+func (r *treeRow) MouseMoved(_ *desktop.MouseEvent) {
+}
+
+func (r *treeRow) MouseOut() {
+	r.hovered = false
+	r.tooltip.dismiss()
+	r.Refresh()
+}
+
+func (r *treeRow) Dragged(ev *fyne.DragEvent) {
+	r.node.Dragged(ev)
+}
+
+func (r *treeRow) DragEnd() {
+	r.node.DragEnd()
+}
+
+func (r *treeRow) CreateRenderer() fyne.WidgetRenderer {
+	r.renderer = newTreeRowRenderer(r)
+	return r.renderer
+}
+
+// style returns the style of the container showing this row.
+func (r *treeRow) style() TreeStyle {
+	if r.tree != nil {
+		return r.tree.style()
+	}
+	return nodeStyle(r.node)
+}
+
+// labelOffset returns the horizontal position of the label within the row.
+func (r *treeRow) labelOffset() int {
+	if r.renderer == nil {
+		return r.depth * r.style().Indent
+	}
+	return r.renderer.label.Position().X
+}
+
+var _ fyne.WidgetRenderer = (*treeRowRenderer)(nil)
+
+type treeRowRenderer struct {
+	row       *treeRow
+	hover     *canvas.Rectangle
+	highlight *canvas.Rectangle
+	focus     *canvas.Rectangle
+	handle    *expandHandle
+	check     *nodeCheck
+	icon      *nodeIcon
+	label     *nodeLabel
+	cells     []*widget.Label
+	guides    guideLines
+}
+
+func newTreeRowRenderer(row *treeRow) *treeRowRenderer {
+	node := row.node
+	hover := canvas.NewRectangle(theme.HoverColor())
+	hover.Hide()
+	highlight := canvas.NewRectangle(theme.FocusColor())
+	highlight.Hide()
+	focus := canvas.NewRectangle(color.Transparent)
+	focus.StrokeColor = theme.PrimaryColor()
+	focus.StrokeWidth = 1
+	focus.Hide()
+	renderer := &treeRowRenderer{
+		row:       row,
+		hover:     hover,
+		highlight: highlight,
+		focus:     focus,
+		handle:    NewExpandHandle(node),
+		check:     newNodeCheck(node),
+		icon:      newNodeIcon(node, node.GetModelIconResource()),
+		label:     newNodeLabel(node, node.GetModelText()),
+	}
+	renderer.Refresh()
+	return renderer
+}
+
+func (r *treeRowRenderer) Layout(size fyne.Size) {
+	r.hover.Move(fyne.NewPos(0, 0))
+	r.hover.Resize(size)
+	r.highlight.Move(fyne.NewPos(0, 0))
+	r.highlight.Resize(size)
+	r.focus.Move(fyne.NewPos(0, 0))
+	r.focus.Resize(size)
+
+	style := r.row.style()
+	var shown map[*TreeNode]bool
+	if r.row.tree != nil {
+		shown = r.row.tree.filterShown()
+	}
+	r.guides.layout(rowGuides(r.row.node, r.row.depth, style, size.Height, shown))
+	iconSize := fyne.NewSize(style.IconSize, style.IconSize)
+	iconY := (size.Height - style.IconSize) / 2
+	x := r.row.depth * style.Indent
+	r.handle.Move(fyne.NewPos(x, iconY))
+	r.handle.Resize(iconSize)
+	x += style.IconSize
+	if check := r.check; check.Visible() {
+		check.Move(fyne.NewPos(x, iconY))
+		check.Resize(iconSize)
+		x += style.IconSize
+	}
+	if icon := r.icon; icon.Resource != nil {
+		icon.Move(fyne.NewPos(x, iconY))
+		icon.Resize(iconSize)
+	}
+	x = r.labelX(style)
+	label := r.label
+	labelSize := label.MinSize()
+	columns := r.tableColumns()
+	if len(columns) > 0 {
+		labelSize.Width = util.IntMax(util.IntMin(labelSize.Width, columns[0].Width-x), 0)
+	}
+	labelY := (size.Height - labelSize.Height) / 2
+	label.Move(fyne.NewPos(x, labelY))
+	label.Resize(labelSize)
+
+	if len(columns) > 0 {
+		x = columns[0].Width
+		for i, cell := range r.cells {
+			if i+1 >= len(columns) {
+				break
+			}
+			width := columns[i+1].Width
+			cell.Move(fyne.NewPos(x, labelY))
+			cell.Resize(fyne.NewSize(width, labelSize.Height))
+			x += width
+		}
+	}
+}
+
+// labelX returns where the label starts, after the indent, the handle, and the checkbox and icon if they're shown.
+func (r *treeRowRenderer) labelX(style TreeStyle) int {
+	x := r.row.depth*style.Indent + style.IconSize
+	if r.check.Visible() {
+		x += style.IconSize
+	}
+	if r.icon.Resource != nil {
+		x += style.IconSize
+	}
+	return x
+}
+
+// tableColumns returns the columns of the TreeTable showing this row, or nil if it isn't in a table.
+func (r *treeRowRenderer) tableColumns() []TableColumn {
+	if c := r.row.node.treeContainer(); c != nil && c.table != nil {
+		return c.table.Columns()
+	}
+	return nil
+}
+
+// refreshCells updates the extra column values when the row is in a TreeTable.
+func (r *treeRowRenderer) refreshCells() {
+	columns := r.tableColumns()
+	count := util.IntMax(len(columns)-1, 0)
+	for len(r.cells) < count {
+		r.cells = append(r.cells, widget.NewLabel(""))
+	}
+	r.cells = r.cells[:count]
+	for i, cell := range r.cells {
+		cell.Alignment = columns[i+1].Alignment
+		cell.SetText(columnText(r.row.node, i+1))
+	}
+}
+
+func (r *treeRowRenderer) MinSize() fyne.Size {
+	style := r.row.style()
+	labelSize := r.label.MinSize()
+	height := style.rowHeight(labelSize.Height)
+	if columns := r.tableColumns(); len(columns) > 0 {
+		var width int
+		for _, column := range columns {
+			width += column.Width
+		}
+		return fyne.NewSize(width, height)
+	}
+	return fyne.NewSize(r.labelX(style)+labelSize.Width, height)
+}
+
+func (r *treeRowRenderer) Refresh() {
+	node := r.row.node
+	r.handle.node = node
+	r.check.node = node
+	r.icon.node = node
+	r.label.node = node
+
+	r.hover.FillColor = theme.HoverColor()
+	if r.row.hovered {
+		r.hover.Show()
+	} else {
+		r.hover.Hide()
+	}
+	r.highlight.FillColor = theme.FocusColor()
+	if node.IsSelected() {
+		r.highlight.Show()
+	} else {
+		r.highlight.Hide()
+	}
+	r.focus.StrokeColor = theme.PrimaryColor()
+	if node.HasFocus() {
+		r.focus.Show()
+	} else {
+		r.focus.Hide()
+	}
+
+	r.handle.Refresh()
+	r.check.Refresh()
+	// Update icon and label from view model
+	iconResource := node.GetModelIconResource()
+	labelText := node.GetModelText()
+
+	r.icon.SetResource(iconResource)
+	if iconResource == nil {
+		r.icon.Hide()
+	} else {
+		r.icon.Show()
+	}
+	r.label.SetText(labelText)
+	if labelText == "" || node.IsRenaming() {
+		r.label.Hide()
+	} else {
+		r.label.Show()
+	}
+	r.refreshCells()
+	r.Layout(r.row.Size())
+	canvas.Refresh(r.row)
+}
+
+func (r *treeRowRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (r *treeRowRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{r.hover, r.highlight, r.focus}
+	objects = append(objects, r.guides.objects()...)
+	objects = append(objects, r.handle, r.check, r.icon, r.label)
+	for _, cell := range r.cells {
+		objects = append(objects, cell)
+	}
+	return objects
+}
+
+func (r *treeRowRenderer) Destroy() {
+	r.handle.node = nil
+	r.handle = nil
+	r.check.node = nil
+	r.check = nil
+	r.icon.node = nil
+	r.icon = nil
+	r.label.node = nil
+	r.label = nil
+	r.cells = nil
+	r.hover = nil
+	r.highlight = nil
+	r.focus = nil
+	r.row.renderer = nil
+	r.row = nil
+}
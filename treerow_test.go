@@ -0,0 +1,108 @@
+package fynetree
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/test"
+)
+
+func rowSetup() (*treeRow, fyne.Window) {
+	traversalSetup()
+	rootNode.Expand()
+	w := showContainer()
+	return rowPart(treeContainer, nodeA, RowPartRow).(*treeRow), w
+}
+
+// rowPart finds the object drawing a part of the node's row among the objects shown under obj, or nil if the node's
+// row isn't shown.
+func rowPart(obj fyne.CanvasObject, node *TreeNode, part RowPart) fyne.CanvasObject {
+	if !obj.Visible() {
+		return nil
+	}
+	if objNode, objPart := RowPartOf(obj); objNode == node && objPart == part {
+		return obj
+	}
+	var children []fyne.CanvasObject
+	switch o := obj.(type) {
+	case *fyne.Container:
+		children = o.Objects
+	case fyne.Widget:
+		children = test.WidgetRenderer(o).Objects()
+	}
+	for _, child := range children {
+		if found := rowPart(child, node, part); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestTreeRow_FullWidth(t *testing.T) {
+	row, w := rowSetup()
+	defer w.Close()
+	if row.Size().Width != treeContainer.viewport.Size().Width {
+		t.Fatalf("Expected row to span the container, got width %d", row.Size().Width)
+	}
+}
+
+func TestTreeRow_Taps(t *testing.T) {
+	row, w := rowSetup()
+	defer w.Close()
+	var doubleTaps, secondaryTaps int
+	nodeA.OnDoubleTapped = func(_ *fyne.PointEvent) {
+		doubleTaps++
+	}
+	nodeA.OnTappedSecondary = func(_ *fyne.PointEvent) {
+		secondaryTaps++
+	}
+
+	row.Tapped(&fyne.PointEvent{})
+	if !nodeA.IsSelected() || treeContainer.FocusedNode() != nodeA {
+		t.Fatalf("Expected tapping the row to select its node")
+	}
+	row.DoubleTapped(&fyne.PointEvent{})
+	row.TappedSecondary(&fyne.PointEvent{})
+	if doubleTaps != 1 || secondaryTaps != 1 {
+		t.Fatalf("Expected row taps to reach the node, got %d double and %d secondary", doubleTaps, secondaryTaps)
+	}
+
+	treeContainer.SelectionMode = SelectionMulti
+	other := rowPart(treeContainer, rootNode, RowPartRow).(*treeRow)
+	other.MouseDown(&desktop.MouseEvent{Modifier: desktop.ControlModifier})
+	other.Tapped(&fyne.PointEvent{})
+	if !nodeA.IsSelected() || !rootNode.IsSelected() {
+		t.Fatalf("Expected held modifier to extend the selection")
+	}
+}
+
+func TestTreeRow_Hover(t *testing.T) {
+	row, w := rowSetup()
+	defer w.Close()
+	if row.renderer.hover.Visible() {
+		t.Fatalf("Expected no hover highlight initially")
+	}
+	row.MouseIn(&desktop.MouseEvent{})
+	if !row.renderer.hover.Visible() || row.renderer.hover.Size() != row.Size() {
+		t.Fatalf("Expected hover highlight to fill the row")
+	}
+	row.MouseOut()
+	if row.renderer.hover.Visible() {
+		t.Fatalf("Expected hover highlight to be hidden after the mouse leaves")
+	}
+}
+
+func TestTreeRow_MinSizeWithoutIcon(t *testing.T) {
+	containerSetup()
+	node := NewLeafTreeNode(NewStaticModel(nil, "No icon"))
+	_ = treeContainer.Append(node)
+	w := showContainer()
+	defer w.Close()
+
+	row := rowPart(treeContainer, node, RowPartRow)
+	label := rowPart(row, node, RowPartLabel)
+	if want := label.Position().X + label.MinSize().Width; row.MinSize().Width != want {
+		t.Fatalf("Expected the row to fit the label at %d, got width %d", want, row.MinSize().Width)
+	}
+}
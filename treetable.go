@@ -0,0 +1,187 @@
+package fynetree
+
+import (
+	"image/color"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+	"github.com/drognisep/fynetree/util"
+)
+
+const (
+	defaultColumnWidth = 150
+	minColumnWidth     = 24
+)
+
+// TableColumn describes a column in a TreeTable. The first column is the tree column, which shows each node's handle,
+// icon and label, and the rest show the values from each node's ColumnModel.
+type TableColumn struct {
+	// Title is shown in the column's header.
+	Title string
+	// Width is the column's width, which can be changed by dragging the edge of its header. Defaults to 150.
+	Width int
+	// Alignment aligns the column's values. The tree column is always aligned to the leading edge.
+	Alignment fyne.TextAlign
+	// Comparator is used to sort siblings by this column. Defaults to a natural sort of the column's text.
+	Comparator NodeComparator
+}
+
+var _ fyne.Widget = (*TreeTable)(nil)
+
+// TreeTable shows a TreeContainer's nodes with extra columns of values, under a header that can be used to resize the
+// columns or sort by them.
+type TreeTable struct {
+	widget.BaseWidget
+	*TreeContainer
+
+	mux           sync.Mutex
+	columns       []TableColumn
+	sortColumn    int
+	sortAscending bool
+	header        *tableHeader
+}
+
+// NewTreeTable creates a TreeTable with the given columns. A single untitled tree column is used if none are given.
+func NewTreeTable(columns ...TableColumn) *TreeTable {
+	if len(columns) == 0 {
+		columns = []TableColumn{{}}
+	}
+	table := &TreeTable{
+		TreeContainer: NewTreeContainer(),
+		columns:       make([]TableColumn, len(columns)),
+		sortColumn:    -1,
+	}
+	copy(table.columns, columns)
+	for i := range table.columns {
+		if table.columns[i].Width <= 0 {
+			table.columns[i].Width = defaultColumnWidth
+		}
+	}
+	table.TreeContainer.table = table
+	table.header = newTableHeader(table)
+	table.ExtendBaseWidget(table)
+	return table
+}
+
+// Columns returns a copy of the table's columns.
+func (t *TreeTable) Columns() []TableColumn {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	columns := make([]TableColumn, len(t.columns))
+	copy(columns, t.columns)
+	return columns
+}
+
+// SetColumnWidth changes the width of a column, down to a minimum width.
+func (t *TreeTable) SetColumnWidth(column, width int) {
+	t.mux.Lock()
+	if column < 0 || column >= len(t.columns) {
+		t.mux.Unlock()
+		return
+	}
+	t.columns[column].Width = util.IntMax(width, minColumnWidth)
+	t.mux.Unlock()
+	t.Refresh()
+}
+
+// SortByColumn sorts the siblings within each branch by the given column once. Each branch keeps its own comparator,
+// so auto-sorted branches and InsertSorted still use it. The sort is recorded in the container's history, so it can
+// be undone.
+func (t *TreeTable) SortByColumn(column int, ascending bool) {
+	t.mux.Lock()
+	if column < 0 || column >= len(t.columns) {
+		t.mux.Unlock()
+		return
+	}
+	compare := t.columns[column].Comparator
+	t.sortColumn = column
+	t.sortAscending = ascending
+	t.mux.Unlock()
+
+	if compare == nil {
+		compare = func(a, b *TreeNode) int {
+			return naturalCompare(columnText(a, column), columnText(b, column))
+		}
+	}
+	if !ascending {
+		ascendingCompare := compare
+		compare = func(a, b *TreeNode) int {
+			return ascendingCompare(b, a)
+		}
+	}
+	sortWith := func(list *nodeList) {
+		list.sortWith(compare)
+	}
+	recordSort(t.History, t.TreeContainer.nodeList.withDescendants(true), sortWith, t.Refresh)
+}
+
+// SortColumn returns the column the table was last sorted by and whether it was ascending, or -1 if it hasn't been sorted.
+func (t *TreeTable) SortColumn() (int, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.sortColumn, t.sortAscending
+}
+
+// toggleSort sorts by the column, reversing the order if the table is already sorted by it.
+func (t *TreeTable) toggleSort(column int) {
+	current, ascending := t.SortColumn()
+	t.SortByColumn(column, current != column || !ascending)
+}
+
+// Refresh redraws the header and rows.
+func (t *TreeTable) Refresh() {
+	t.header.Refresh()
+	t.TreeContainer.Refresh()
+	t.BaseWidget.Refresh()
+}
+
+func (t *TreeTable) CreateRenderer() fyne.WidgetRenderer {
+	return &treeTableRenderer{
+		table: t,
+	}
+}
+
+// columnText gets the text shown for the node in the given column.
+func columnText(node *TreeNode, column int) string {
+	if column == 0 {
+		return node.GetModelText()
+	}
+	if model, ok := node.model.(ColumnModel); ok {
+		return model.GetColumnText(column)
+	}
+	return ""
+}
+
+var _ fyne.WidgetRenderer = (*treeTableRenderer)(nil)
+
+type treeTableRenderer struct {
+	table *TreeTable
+}
+
+func (r *treeTableRenderer) Layout(size fyne.Size) {
+	headerHeight := r.table.header.MinSize().Height
+	r.table.header.Move(fyne.NewPos(0, 0))
+	r.table.header.Resize(fyne.NewSize(size.Width, headerHeight))
+	r.table.TreeContainer.Move(fyne.NewPos(0, headerHeight))
+	r.table.TreeContainer.Resize(fyne.NewSize(size.Width, size.Height-headerHeight))
+}
+
+func (r *treeTableRenderer) MinSize() fyne.Size {
+	return util.ColumnMinSize(r.table.header.MinSize(), r.table.TreeContainer.MinSize())
+}
+
+func (r *treeTableRenderer) Refresh() {
+	r.Layout(r.table.Size())
+}
+
+func (r *treeTableRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (r *treeTableRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.table.header, r.table.TreeContainer}
+}
+
+func (r *treeTableRenderer) Destroy() {
+}
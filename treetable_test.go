@@ -0,0 +1,138 @@
+package fynetree
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"fyne.io/fyne/widget"
+)
+
+type columnModel struct {
+	StaticNodeModel
+	values []string
+}
+
+func (c *columnModel) GetColumnText(column int) string {
+	if column-1 < len(c.values) {
+		return c.values[column-1]
+	}
+	return ""
+}
+
+func newColumnNode(text string, values ...string) *TreeNode {
+	return NewTreeNode(&columnModel{StaticNodeModel{Text: text}, values})
+}
+
+// tableSetup builds a table with root -> (b, a -> (d, c)), where the size column orders them differently to the names.
+func tableSetup() (*TreeTable, *TreeNode) {
+	test.NewApp()
+	table := NewTreeTable(
+		TableColumn{Title: "Name"},
+		TableColumn{Title: "Size", Width: 80, Alignment: fyne.TextAlignTrailing},
+	)
+	root := newColumnNode("root", "0")
+	a := newColumnNode("a", "20")
+	b := newColumnNode("b", "10")
+	_ = a.Append(newColumnNode("d", "1"))
+	_ = a.Append(newColumnNode("c", "2"))
+	_ = root.Append(b)
+	_ = root.Append(a)
+	_ = table.Append(root)
+	root.Expand()
+	a.Expand()
+	return table, root
+}
+
+func joinedChildTexts(node *TreeNode) string {
+	return strings.Join(childTexts(node), ",")
+}
+
+func TestNewTreeTable(t *testing.T) {
+	table := NewTreeTable()
+	if columns := table.Columns(); len(columns) != 1 || columns[0].Width != defaultColumnWidth {
+		t.Fatalf("Expected a single default tree column, got %v", columns)
+	}
+	if column, _ := table.SortColumn(); column != -1 {
+		t.Fatalf("Expected a new table to be unsorted")
+	}
+}
+
+func TestTreeTable_Cells(t *testing.T) {
+	table, root := tableSetup()
+	w := test.NewWindow(table)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 300))
+
+	row := rowPart(table, root, RowPartRow)
+	if row == nil {
+		t.Fatalf("Expected a row to show the root node")
+	}
+	var cells []*widget.Label
+	for _, obj := range test.WidgetRenderer(row.(fyne.Widget)).Objects() {
+		if cell, ok := obj.(*widget.Label); ok {
+			cells = append(cells, cell)
+		}
+	}
+	if len(cells) != 1 || cells[0].Text != "0" || cells[0].Alignment != fyne.TextAlignTrailing {
+		t.Fatalf("Expected a trailing aligned size cell, got %v", cells)
+	}
+	if got := table.MinSize().Width; got < defaultColumnWidth+80 {
+		t.Fatalf("Expected table to be at least as wide as its columns, got %d", got)
+	}
+}
+
+func TestTreeTable_SortByColumn(t *testing.T) {
+	table, root := tableSetup()
+	a := root.Children()[1]
+
+	table.SortByColumn(0, true)
+	if joinedChildTexts(root) != "a,b" || joinedChildTexts(a) != "c,d" {
+		t.Fatalf("Expected ascending name order, got %s and %s", joinedChildTexts(root), joinedChildTexts(a))
+	}
+	table.SortByColumn(1, false)
+	if joinedChildTexts(root) != "a,b" || joinedChildTexts(a) != "c,d" {
+		t.Fatalf("Expected descending size order, got %s and %s", joinedChildTexts(root), joinedChildTexts(a))
+	}
+	table.toggleSort(1)
+	if column, ascending := table.SortColumn(); column != 1 || !ascending {
+		t.Fatalf("Expected toggling the sorted column to reverse it")
+	}
+	if joinedChildTexts(root) != "b,a" || joinedChildTexts(a) != "d,c" {
+		t.Fatalf("Expected ascending size order, got %s and %s", joinedChildTexts(root), joinedChildTexts(a))
+	}
+	_ = root.InsertSorted(newColumnNode("c", "5"))
+	if got := joinedChildTexts(root); got != "b,a,c" {
+		t.Fatalf("Expected sorting by a column to keep the branch's comparator, got %s", got)
+	}
+
+	table.History = NewHistory()
+	table.SortByColumn(0, true)
+	_ = table.Undo()
+	if got := joinedChildTexts(root); got != "b,a,c" {
+		t.Fatalf("Expected undo to restore the order before sorting, got %s", got)
+	}
+}
+
+func TestTreeTable_SetColumnWidth(t *testing.T) {
+	table, _ := tableSetup()
+	table.SetColumnWidth(1, 120)
+	if width := table.Columns()[1].Width; width != 120 {
+		t.Fatalf("Expected width 120, got %d", width)
+	}
+	divider := newHeaderDivider(table, 1)
+	divider.Dragged(&fyne.DragEvent{DraggedX: -200})
+	if width := table.Columns()[1].Width; width != minColumnWidth {
+		t.Fatalf("Expected width to be clamped to %d, got %d", minColumnWidth, width)
+	}
+	before := table.Columns()
+	table.SetColumnWidth(5, 100)
+	table.SetColumnWidth(-1, 100)
+	after := table.Columns()
+	for i := range before {
+		if before[i].Width != after[i].Width {
+			t.Fatalf("Expected an unknown column to be ignored, but column %d changed to %d", i, after[i].Width)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Package treetest drives a fynetree.TreeContainer or TreeTable in fyne's headless test driver. It finds rows by the
+// text of the nodes along their path, taps the parts of a row and types keys like a user would, and asserts on what's
+// shown.
+package treetest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/test"
+	"fyne.io/fyne/widget"
+	"github.com/drognisep/fynetree"
+)
+
+// PathSeparator joins the text of the nodes in a path, in the paths returned and asserted on by Tree.
+const PathSeparator = "/"
+
+// Tree shows a TreeContainer or TreeTable in a test window. Methods fail the test if a row or node can't be found.
+type Tree struct {
+	// Container is the container being tested, or the container of the table being tested.
+	Container *fynetree.TreeContainer
+	// Window is the test window showing the container.
+	Window fyne.Window
+
+	t *testing.T
+}
+
+// NewTree shows the container in a new test window of the given size. A test app is created if there isn't one.
+// Call Close when the test is done.
+func NewTree(t *testing.T, container *fynetree.TreeContainer, size fyne.Size) *Tree {
+	return newTree(t, container, container, size)
+}
+
+// NewTable shows the table in a new test window of the given size, like NewTree.
+func NewTable(t *testing.T, table *fynetree.TreeTable, size fyne.Size) *Tree {
+	return newTree(t, table.TreeContainer, table, size)
+}
+
+func newTree(t *testing.T, container *fynetree.TreeContainer, content fyne.CanvasObject, size fyne.Size) *Tree {
+	if fyne.CurrentApp() == nil {
+		test.NewApp()
+	}
+	w := test.NewWindow(content)
+	w.Resize(size)
+	return &Tree{Container: container, Window: w, t: t}
+}
+
+// Close closes the test window.
+func (tr *Tree) Close() {
+	tr.Window.Close()
+}
+
+// Node finds the node reached by following the text of each node along the path from a root, whether or not it's
+// shown.
+func (tr *Tree) Node(path ...string) *fynetree.TreeNode {
+	tr.t.Helper()
+	if len(path) == 0 {
+		tr.t.Fatalf("Empty node path")
+	}
+	candidates := tr.Container.Children()
+	var node *fynetree.TreeNode
+	for i, text := range path {
+		node = nil
+		for _, candidate := range candidates {
+			if candidate.GetModelText() == text {
+				node = candidate
+				break
+			}
+		}
+		if node == nil {
+			tr.t.Fatalf("No node at %s", strings.Join(path[:i+1], PathSeparator))
+		}
+		candidates = node.Children()
+	}
+	return node
+}
+
+// Path returns the text of the nodes from the root down to the node, joined with PathSeparator.
+func Path(node *fynetree.TreeNode) string {
+	texts := []string{node.GetModelText()}
+	for parent := node.GetParent(); parent != nil; parent = parent.GetParent() {
+		texts = append([]string{parent.GetModelText()}, texts...)
+	}
+	return strings.Join(texts, PathSeparator)
+}
+
+// Tap taps anywhere on the row of the node at the path, selecting it.
+func (tr *Tree) Tap(path ...string) {
+	tr.t.Helper()
+	test.Tap(tr.part(fynetree.RowPartRow, path).(fyne.Tappable))
+}
+
+// TapWithModifier taps the row of the node at the path while holding the modifier keys, to extend the selection.
+func (tr *Tree) TapWithModifier(modifier desktop.Modifier, path ...string) {
+	tr.t.Helper()
+	row := tr.part(fynetree.RowPartRow, path)
+	row.(desktop.Mouseable).MouseDown(&desktop.MouseEvent{Button: desktop.LeftMouseButton, Modifier: modifier})
+	test.Tap(row.(fyne.Tappable))
+	row.(desktop.Mouseable).MouseUp(&desktop.MouseEvent{Button: desktop.LeftMouseButton, Modifier: modifier})
+}
+
+// TapHandle taps the expand handle of the node at the path, expanding or condensing it.
+func (tr *Tree) TapHandle(path ...string) {
+	tr.t.Helper()
+	test.Tap(tr.part(fynetree.RowPartHandle, path).(fyne.Tappable))
+}
+
+// TapIcon taps the icon of the node at the path.
+func (tr *Tree) TapIcon(path ...string) {
+	tr.t.Helper()
+	test.Tap(tr.part(fynetree.RowPartIcon, path).(fyne.Tappable))
+}
+
+// TapLabel taps the label of the node at the path.
+func (tr *Tree) TapLabel(path ...string) {
+	tr.t.Helper()
+	test.Tap(tr.part(fynetree.RowPartLabel, path).(fyne.Tappable))
+}
+
+// DoubleTap double taps the row of the node at the path.
+func (tr *Tree) DoubleTap(path ...string) {
+	tr.t.Helper()
+	test.DoubleTap(tr.part(fynetree.RowPartRow, path).(fyne.DoubleTappable))
+}
+
+// TapSecondary taps the row of the node at the path with the secondary button, showing its context menu.
+func (tr *Tree) TapSecondary(path ...string) {
+	tr.t.Helper()
+	test.TapSecondary(tr.part(fynetree.RowPartRow, path).(fyne.SecondaryTappable))
+}
+
+// TapCheck taps the checkbox of the node at the path, checking or unchecking it.
+func (tr *Tree) TapCheck(path ...string) {
+	tr.t.Helper()
+	test.Tap(tr.part(fynetree.RowPartCheck, path).(fyne.Tappable))
+}
+
+// TypeRename replaces the text in the focused rename editor and types the key, such as fyne.KeyReturn to commit the
+// rename or fyne.KeyEscape to cancel it.
+func (tr *Tree) TypeRename(text string, key fyne.KeyName) {
+	tr.t.Helper()
+	focused := tr.Window.Canvas().Focused()
+	editor, ok := focused.(interface{ SetText(string) })
+	if !ok {
+		tr.t.Fatalf("No rename editor is focused")
+	}
+	editor.SetText(text)
+	focused.TypedKey(&fyne.KeyEvent{Name: key})
+}
+
+// Cells returns the labels drawn for the table columns after the tree column in the row of the node at the path.
+func (tr *Tree) Cells(path ...string) []*widget.Label {
+	tr.t.Helper()
+	var cells []*widget.Label
+	for _, obj := range test.WidgetRenderer(tr.part(fynetree.RowPartRow, path).(fyne.Widget)).Objects() {
+		if cell, ok := obj.(*widget.Label); ok {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// PressKey focuses the container and types the key, as if it was pressed and released.
+func (tr *Tree) PressKey(name fyne.KeyName) {
+	tr.t.Helper()
+	c := tr.Window.Canvas()
+	if c.Focused() != tr.Container {
+		c.Focus(tr.Container)
+	}
+	event := &fyne.KeyEvent{Name: name}
+	tr.Container.KeyDown(event)
+	tr.Container.TypedKey(event)
+	tr.Container.KeyUp(event)
+}
+
+// PressShortcut focuses the container and types the shortcut.
+func (tr *Tree) PressShortcut(shortcut fyne.Shortcut) {
+	tr.t.Helper()
+	c := tr.Window.Canvas()
+	if c.Focused() != tr.Container {
+		c.Focus(tr.Container)
+	}
+	tr.Container.TypedShortcut(shortcut)
+}
+
+// VisibleRows returns the path of each node with a row drawn by the container, from top to bottom. Rows are drawn for
+// the nodes scrolled into view.
+func (tr *Tree) VisibleRows() []string {
+	rows := tr.rows()
+	paths := make([]string, len(rows))
+	for i, row := range rows {
+		node, _ := fynetree.RowPartOf(row)
+		paths[i] = Path(node)
+	}
+	return paths
+}
+
+// SelectedPaths returns the path of each selected node, in the order they were selected.
+func (tr *Tree) SelectedPaths() []string {
+	var paths []string
+	for _, node := range tr.Container.SelectedNodes() {
+		paths = append(paths, Path(node))
+	}
+	return paths
+}
+
+// AssertVisibleRows fails the test unless the rows drawn are for the given paths, from top to bottom.
+func (tr *Tree) AssertVisibleRows(want ...string) {
+	tr.t.Helper()
+	if got := tr.VisibleRows(); !equal(got, want) {
+		tr.t.Fatalf("Expected visible rows %v, got %v", want, got)
+	}
+}
+
+// AssertSelected fails the test unless the selected nodes are the given paths, in the order they were selected.
+func (tr *Tree) AssertSelected(want ...string) {
+	tr.t.Helper()
+	if got := tr.SelectedPaths(); !equal(got, want) {
+		tr.t.Fatalf("Expected selection %v, got %v", want, got)
+	}
+}
+
+// AssertExpanded fails the test unless the node at the path is expanded.
+func (tr *Tree) AssertExpanded(path ...string) {
+	tr.t.Helper()
+	if !tr.Node(path...).IsExpanded() {
+		tr.t.Fatalf("Expected %s to be expanded", strings.Join(path, PathSeparator))
+	}
+}
+
+// AssertCondensed fails the test unless the node at the path is condensed.
+func (tr *Tree) AssertCondensed(path ...string) {
+	tr.t.Helper()
+	if tr.Node(path...).IsExpanded() {
+		tr.t.Fatalf("Expected %s to be condensed", strings.Join(path, PathSeparator))
+	}
+}
+
+// part finds the object drawing a part of the row of the node at the path. The row must be shown in the window.
+func (tr *Tree) part(part fynetree.RowPart, path []string) fyne.CanvasObject {
+	tr.t.Helper()
+	node := tr.Node(path...)
+	for _, row := range tr.rows() {
+		if rowNode, _ := fynetree.RowPartOf(row); rowNode != node {
+			continue
+		}
+		if part == fynetree.RowPartRow {
+			return row
+		}
+		for _, obj := range test.WidgetRenderer(row.(fyne.Widget)).Objects() {
+			if objNode, objPart := fynetree.RowPartOf(obj); objNode == node && objPart == part && obj.Visible() {
+				return obj
+			}
+		}
+		tr.t.Fatalf("The row of %s has no part %d shown", strings.Join(path, PathSeparator), part)
+	}
+	tr.t.Fatalf("No row shown for %s", strings.Join(path, PathSeparator))
+	return nil
+}
+
+// rows finds the rows drawn by the container, sorted from top to bottom.
+func (tr *Tree) rows() []fyne.CanvasObject {
+	var rows []fyne.CanvasObject
+	collectRows(tr.Window.Content(), &rows)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Position().Y < rows[j].Position().Y
+	})
+	return rows
+}
+
+// collectRows walks the object's renderers and containers for rows that are shown.
+func collectRows(obj fyne.CanvasObject, rows *[]fyne.CanvasObject) {
+	if !obj.Visible() {
+		return
+	}
+	if _, part := fynetree.RowPartOf(obj); part == fynetree.RowPartRow {
+		*rows = append(*rows, obj)
+		return
+	}
+	switch o := obj.(type) {
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			collectRows(child, rows)
+		}
+	case fyne.Widget:
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			collectRows(child, rows)
+		}
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package treetest
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/theme"
+	"github.com/drognisep/fynetree"
+)
+
+func newNode(text string, children ...*fynetree.TreeNode) *fynetree.TreeNode {
+	node := fynetree.NewTreeNode(fynetree.NewStaticModel(theme.FileIcon(), text))
+	for _, child := range children {
+		_ = node.Append(child)
+	}
+	if len(children) == 0 {
+		node.SetLeaf()
+	}
+	return node
+}
+
+func harnessSetup(t *testing.T) *Tree {
+	container := fynetree.NewTreeContainer()
+	_ = container.Append(newNode("Root", newNode("A", newNode("C"), newNode("D")), newNode("B")))
+	return NewTree(t, container, fyne.NewSize(300, 300))
+}
+
+func TestTree_TapHandleExpands(t *testing.T) {
+	tr := harnessSetup(t)
+	defer tr.Close()
+	tr.AssertVisibleRows("Root")
+	tr.AssertCondensed("Root")
+
+	tr.TapHandle("Root")
+	tr.AssertExpanded("Root")
+	tr.AssertVisibleRows("Root", "Root/A", "Root/B")
+	tr.TapHandle("Root", "A")
+	tr.AssertVisibleRows("Root", "Root/A", "Root/A/C", "Root/A/D", "Root/B")
+	tr.TapHandle("Root", "A")
+	tr.AssertCondensed("Root", "A")
+	if got := Path(tr.Node("Root", "A", "D")); got != "Root/A/D" {
+		t.Fatalf("Expected hidden nodes to be found by path, got %s", got)
+	}
+}
+
+func TestTree_TapsSelect(t *testing.T) {
+	tr := harnessSetup(t)
+	defer tr.Close()
+	tr.Container.SelectionMode = fynetree.SelectionMulti
+	tr.TapHandle("Root")
+
+	tr.TapLabel("Root", "A")
+	tr.AssertSelected("Root/A")
+	tr.TapIcon("Root", "B")
+	tr.AssertSelected("Root/B")
+	tr.Tap("Root")
+	tr.TapWithModifier(desktop.ControlModifier, "Root", "B")
+	tr.AssertSelected("Root", "Root/B")
+
+	var activated, secondary int
+	tr.Node("Root", "B").OnDoubleTapped = func(_ *fyne.PointEvent) {
+		activated++
+	}
+	tr.Node("Root", "B").OnTappedSecondary = func(_ *fyne.PointEvent) {
+		secondary++
+	}
+	tr.DoubleTap("Root", "B")
+	tr.TapSecondary("Root", "B")
+	if activated != 1 || secondary != 1 {
+		t.Fatalf("Expected double and secondary taps to reach the node, got %d and %d", activated, secondary)
+	}
+}
+
+func TestTree_PressKey(t *testing.T) {
+	tr := harnessSetup(t)
+	defer tr.Close()
+	tr.Tap("Root")
+	tr.PressKey(fyne.KeyRight)
+	tr.AssertExpanded("Root")
+	tr.PressKey(fyne.KeyDown)
+	tr.PressKey(fyne.KeySpace)
+	tr.AssertSelected("Root/A")
+	tr.PressKey(fyne.KeyRight)
+	tr.AssertVisibleRows("Root", "Root/A", "Root/A/C", "Root/A/D", "Root/B")
+
+	tr.Container.History = fynetree.NewHistory()
+	tr.PressKey(fyne.KeyLeft)
+	tr.AssertCondensed("Root", "A")
+	tr.PressShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier})
+	tr.AssertExpanded("Root", "A")
+}
+
+type editableModel struct {
+	fynetree.StaticNodeModel
+}
+
+func (e *editableModel) SetText(text string) error {
+	e.Text = text
+	return nil
+}
+
+func TestTree_CheckAndRename(t *testing.T) {
+	tr := harnessSetup(t)
+	defer tr.Close()
+	tr.Container.Checkboxes = true
+	tr.Container.InlineRename = true
+	tr.Container.Refresh()
+
+	tr.TapCheck("Root")
+	if !tr.Node("Root").IsChecked() || !tr.Node("Root", "B").IsChecked() {
+		t.Fatalf("Expected tapping the checkbox to check the node and its children")
+	}
+
+	node := fynetree.NewTreeNode(&editableModel{fynetree.StaticNodeModel{Text: "Before"}})
+	node.SetLeaf()
+	_ = tr.Container.Append(node)
+	if err := tr.Container.StartRename(node); err != nil {
+		t.Fatalf("Failed to start rename: %v", err)
+	}
+	tr.TypeRename("After", fyne.KeyReturn)
+	if node.GetModelText() != "After" || node.IsRenaming() {
+		t.Fatalf("Expected the rename to be committed, got '%s'", node.GetModelText())
+	}
+}
+
+type columnModel struct {
+	fynetree.StaticNodeModel
+	size string
+}
+
+func (c *columnModel) GetColumnText(_ int) string {
+	return c.size
+}
+
+func TestTree_TableCells(t *testing.T) {
+	table := fynetree.NewTreeTable(
+		fynetree.TableColumn{Title: "Name"},
+		fynetree.TableColumn{Title: "Size", Alignment: fyne.TextAlignTrailing},
+	)
+	_ = table.Append(fynetree.NewLeafTreeNode(&columnModel{fynetree.StaticNodeModel{Text: "Root"}, "10"}))
+	tr := NewTable(t, table, fyne.NewSize(400, 300))
+	defer tr.Close()
+
+	tr.AssertVisibleRows("Root")
+	cells := tr.Cells("Root")
+	if len(cells) != 1 || cells[0].Text != "10" || cells[0].Alignment != fyne.TextAlignTrailing {
+		t.Fatalf("Expected a trailing aligned size cell, got %v", cells)
+	}
+}
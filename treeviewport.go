@@ -0,0 +1,287 @@
+package fynetree
+
+import (
+	"image/color"
+	"sync"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/container"
+	"fyne.io/fyne/theme"
+	"fyne.io/fyne/widget"
+	"github.com/drognisep/fynetree/util"
+)
+
+var _ fyne.Widget = (*treeViewport)(nil)
+
+// treeViewport is the scrolled content of a TreeContainer. It's sized to fit every visible row, but only creates row
+// widgets for the rows inside the scrolled area, and rebinds them to other nodes as the tree is scrolled.
+type treeViewport struct {
+	widget.BaseWidget
+
+	tree     *TreeContainer
+	scroll   *container.Scroll
+	editor   *renameEntry
+	renderer *treeViewportRenderer
+}
+
+func newTreeViewport(tree *TreeContainer) *treeViewport {
+	v := &treeViewport{
+		tree: tree,
+	}
+	v.ExtendBaseWidget(v)
+	v.scroll = container.NewVScroll(v)
+	v.editor = newRenameEntry(tree)
+	return v
+}
+
+// Move is called by the scroll container when it's scrolled, so the rows in view are updated.
+func (v *treeViewport) Move(pos fyne.Position) {
+	v.BaseWidget.Move(pos)
+	if v.renderer != nil {
+		v.renderer.layoutRows(false)
+	}
+}
+
+func (v *treeViewport) CreateRenderer() fyne.WidgetRenderer {
+	dropIndicator := canvas.NewRectangle(color.Transparent)
+	dropIndicator.StrokeWidth = 2
+	dropIndicator.Hide()
+	v.renderer = &treeViewportRenderer{
+		viewport:      v,
+		dropIndicator: dropIndicator,
+	}
+	return v.renderer
+}
+
+// rowHeight returns the height shared by every row in the tree.
+func (v *treeViewport) rowHeight() int {
+	if v.renderer == nil {
+		return util.IntMax(newTreeViewportTemplateRow(v.tree).MinSize().Height, 1)
+	}
+	return v.renderer.templateRowHeight()
+}
+
+// rowBounds returns the position and size of the node's row relative to the viewport, or a zero size if it's not visible.
+func (v *treeViewport) rowBounds(node *TreeNode) (fyne.Position, fyne.Size) {
+	i := indexOfNode(v.tree.visibleNodes(), node)
+	if i < 0 {
+		return fyne.NewPos(0, 0), fyne.NewSize(0, 0)
+	}
+	height := v.rowHeight()
+	return fyne.NewPos(0, i*height), fyne.NewSize(v.Size().Width, height)
+}
+
+// scrollTo scrolls the minimum distance needed to bring the node's row into view.
+func (v *treeViewport) scrollTo(node *TreeNode) {
+	pos, size := v.rowBounds(node)
+	if size.Height == 0 {
+		return
+	}
+	offset := v.scroll.Offset.Y
+	viewHeight := v.scroll.Size().Height
+	if pos.Y < offset {
+		v.scroll.Offset.Y = pos.Y
+	} else if pos.Y+size.Height > offset+viewHeight {
+		v.scroll.Offset.Y = pos.Y + size.Height - viewHeight
+	} else {
+		return
+	}
+	v.scroll.Refresh()
+}
+
+func newTreeViewportTemplateRow(tree *TreeContainer) *treeRow {
+	row := newTreeRow(NewTreeNode(NewStaticModel(theme.FileIcon(), "Template")), 0)
+	row.tree = tree
+	return row
+}
+
+var _ fyne.WidgetRenderer = (*treeViewportRenderer)(nil)
+
+type treeViewportRenderer struct {
+	viewport      *treeViewport
+	dropIndicator *canvas.Rectangle
+
+	mux      sync.Mutex
+	template *treeRow
+	rows     []*treeRow
+	pool     []*treeRow
+}
+
+func (r *treeViewportRenderer) templateRowHeight() int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.template == nil {
+		r.template = newTreeViewportTemplateRow(r.viewport.tree)
+	}
+	return util.IntMax(r.template.MinSize().Height, 1)
+}
+
+func (r *treeViewportRenderer) Layout(_ fyne.Size) {
+	r.layoutRows(false)
+}
+
+// layoutRows binds a row widget to each node in the scrolled area, reusing rows that have scrolled out of view.
+// Rows that stay in view keep their node, and are only redrawn if refresh is set.
+func (r *treeViewportRenderer) layoutRows(refresh bool) {
+	tree := r.viewport.tree
+	visible := tree.visibleNodes()
+	rowHeight := r.templateRowHeight()
+	width := r.viewport.Size().Width
+
+	offset := util.IntMax(-r.viewport.Position().Y, 0)
+	viewHeight := r.viewport.scroll.Size().Height
+	first := offset / rowHeight
+	count := 0
+	if viewHeight > 0 {
+		count = viewHeight/rowHeight + 2
+	}
+	if first+count > len(visible) {
+		count = util.IntMax(len(visible)-first, 0)
+	}
+
+	r.mux.Lock()
+	bound := make(map[*TreeNode]*treeRow, len(r.rows))
+	for _, row := range r.rows {
+		node, _ := row.boundNode()
+		bound[node] = row
+	}
+	rows := make([]*treeRow, count)
+	for i := range rows {
+		if row, ok := bound[visible[first+i]]; ok {
+			rows[i] = row
+			delete(bound, visible[first+i])
+		}
+	}
+	var spare []*treeRow
+	for _, row := range r.rows {
+		if node, _ := row.boundNode(); bound[node] == row {
+			spare = append(spare, row)
+		}
+	}
+	for i, row := range rows {
+		if row != nil {
+			continue
+		}
+		if len(spare) > 0 {
+			rows[i] = spare[len(spare)-1]
+			spare = spare[:len(spare)-1]
+		} else if len(r.pool) > 0 {
+			rows[i] = r.pool[len(r.pool)-1]
+			r.pool = r.pool[:len(r.pool)-1]
+		} else {
+			rows[i] = newTreeRow(visible[first+i], 0)
+			rows[i].tree = r.viewport.tree
+		}
+	}
+	for _, row := range spare {
+		row.hovered = false
+		row.tooltip.dismiss()
+		r.pool = append(r.pool, row)
+	}
+	r.rows = rows
+	r.mux.Unlock()
+
+	for i, row := range rows {
+		node := visible[first+i]
+		depth := node.Depth()
+		if boundNode, boundDepth := row.boundNode(); refresh || boundNode != node || boundDepth != depth {
+			row.bind(node, depth)
+		}
+		row.Move(fyne.NewPos(0, (first+i)*rowHeight))
+		row.Resize(fyne.NewSize(width, rowHeight))
+	}
+	r.updateEditor(rows, rowHeight, width)
+	r.updateDropIndicator()
+}
+
+// updateEditor shows the rename entry over the label of the node being renamed, if its row is in view.
+func (r *treeViewportRenderer) updateEditor(rows []*treeRow, rowHeight, width int) {
+	editor := r.viewport.editor
+	renaming := r.viewport.tree.RenamingNode()
+	for _, row := range rows {
+		if renaming != nil && row.node == renaming {
+			x := row.labelOffset()
+			editor.Move(fyne.NewPos(x, row.Position().Y))
+			editor.Resize(fyne.NewSize(util.IntMax(width-x, editor.MinSize().Width), rowHeight))
+			editor.Show()
+			return
+		}
+	}
+	editor.Hide()
+}
+
+func (r *treeViewportRenderer) MinSize() fyne.Size {
+	rowHeight := r.templateRowHeight()
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	var width int
+	for _, row := range r.rows {
+		width = util.IntMax(width, row.MinSize().Width)
+	}
+	return fyne.NewSize(width, len(r.viewport.tree.visibleNodes())*rowHeight)
+}
+
+func (r *treeViewportRenderer) Refresh() {
+	r.mux.Lock()
+	r.template = nil
+	r.mux.Unlock()
+	r.layoutRows(true)
+	canvas.Refresh(r.viewport)
+}
+
+// updateDropIndicator draws a line between rows for sibling drops, or an outline around the target row for drops into a branch.
+func (r *treeViewportRenderer) updateDropIndicator() {
+	tree := r.viewport.tree
+	tree.mux.Lock()
+	target := tree.dropTarget
+	position := tree.dropPosition
+	tree.mux.Unlock()
+	if target == nil {
+		r.dropIndicator.Hide()
+		return
+	}
+
+	rowPos, rowSize := r.viewport.rowBounds(target)
+	rowPos.X = target.Depth() * tree.style().Indent
+	rowSize.Width -= rowPos.X
+	indicator := r.dropIndicator
+	indicator.StrokeColor = theme.PrimaryColor()
+	switch position {
+	case DropInto:
+		indicator.FillColor = color.Transparent
+		indicator.Move(rowPos)
+		indicator.Resize(rowSize)
+	case DropAfter:
+		indicator.FillColor = theme.PrimaryColor()
+		indicator.Move(fyne.NewPos(rowPos.X, rowPos.Y+rowSize.Height-1))
+		indicator.Resize(fyne.NewSize(rowSize.Width, 2))
+	default:
+		indicator.FillColor = theme.PrimaryColor()
+		indicator.Move(fyne.NewPos(rowPos.X, rowPos.Y-1))
+		indicator.Resize(fyne.NewSize(rowSize.Width, 2))
+	}
+	indicator.Show()
+	indicator.Refresh()
+}
+
+func (r *treeViewportRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (r *treeViewportRenderer) Objects() []fyne.CanvasObject {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	objects := make([]fyne.CanvasObject, 0, len(r.rows)+2)
+	for _, row := range r.rows {
+		objects = append(objects, row)
+	}
+	return append(objects, r.viewport.editor, r.dropIndicator)
+}
+
+func (r *treeViewportRenderer) Destroy() {
+	r.viewport.renderer = nil
+	r.rows = nil
+	r.pool = nil
+	r.template = nil
+}
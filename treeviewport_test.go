@@ -0,0 +1,141 @@
+package fynetree
+
+import (
+	"fmt"
+	"testing"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"fyne.io/fyne/theme"
+)
+
+func largeTreeSetup(roots, children int) *TreeContainer {
+	c := NewTreeContainer()
+	for i := 0; i < roots; i++ {
+		root := NewTreeNode(NewStaticModel(theme.FolderIcon(), fmt.Sprintf("Root %d", i)))
+		for j := 0; j < children; j++ {
+			_ = root.Append(NewLeafTreeNode(NewStaticModel(theme.FileIcon(), fmt.Sprintf("Child %d.%d", i, j))))
+		}
+		root.Expand()
+		_ = c.Append(root)
+	}
+	return c
+}
+
+func TestTreeViewport_OnlyCreatesRowsInView(t *testing.T) {
+	test.NewApp()
+	c := largeTreeSetup(100, 200)
+	w := test.NewWindow(c)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+
+	renderer := c.viewport.renderer
+	if renderer == nil {
+		t.Fatalf("Viewport was not rendered")
+	}
+	rowHeight := c.viewport.rowHeight()
+	maxRows := c.viewport.scroll.Size().Height/rowHeight + 2
+	if len(renderer.rows) == 0 || len(renderer.rows) > maxRows {
+		t.Fatalf("Expected between 1 and %d rows, got %d", maxRows, len(renderer.rows))
+	}
+	if want := 100 * 201 * rowHeight; c.viewport.MinSize().Height != want {
+		t.Fatalf("Expected viewport height %d, got %d", want, c.viewport.MinSize().Height)
+	}
+}
+
+func TestTreeViewport_RebindsRowsOnScroll(t *testing.T) {
+	test.NewApp()
+	c := largeTreeSetup(10, 100)
+	w := test.NewWindow(c)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+
+	visible := c.visibleNodes()
+	target := visible[500]
+	c.viewport.scrollTo(target)
+
+	renderer := c.viewport.renderer
+	rows := len(renderer.rows)
+	found := false
+	for _, row := range renderer.rows {
+		if row.node == target {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected a row to be bound to '%s' after scrolling", target.GetModelText())
+	}
+	if renderer.rows[0].node == visible[0] {
+		t.Fatalf("Expected first row to be rebound after scrolling")
+	}
+	if rows != len(renderer.rows) {
+		t.Fatalf("Expected row count to stay at %d, got %d", rows, len(renderer.rows))
+	}
+}
+
+func TestTreeContainer_BatchDefersRefresh(t *testing.T) {
+	test.NewApp()
+	c := largeTreeSetup(1, 0)
+	w := test.NewWindow(c)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+	root := c.Children()[0]
+
+	c.Batch(func() {
+		for i := 0; i < 3; i++ {
+			_ = root.Append(NewLeafTreeNode(NewStaticModel(theme.FileIcon(), fmt.Sprintf("Child %d", i))))
+		}
+		if got := len(c.visibleNodes()); got != 4 {
+			t.Fatalf("Expected appended nodes to be visible during the batch, got %d rows", got)
+		}
+		if got := len(c.viewport.renderer.rows); got != 1 {
+			t.Fatalf("Expected rows to be laid out after the batch, got %d", got)
+		}
+	})
+	if got := len(c.viewport.renderer.rows); got != 4 {
+		t.Fatalf("Expected 4 rows after the batch, got %d", got)
+	}
+}
+
+func BenchmarkTreeContainer_Append20k(b *testing.B) {
+	test.NewApp()
+	for i := 0; i < b.N; i++ {
+		c := largeTreeSetup(1, 0)
+		w := test.NewWindow(c)
+		w.Resize(fyne.NewSize(300, 400))
+		root := c.Children()[0]
+		c.Batch(func() {
+			for j := 0; j < 20000; j++ {
+				_ = root.Append(NewLeafTreeNode(NewStaticModel(theme.FileIcon(), fmt.Sprintf("Child %d", j))))
+			}
+		})
+		w.Close()
+	}
+}
+
+func BenchmarkTreeNode_Expand20k(b *testing.B) {
+	test.NewApp()
+	c := largeTreeSetup(1, 20000)
+	w := test.NewWindow(c)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+	root := c.Children()[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		root.Condense()
+		root.Expand()
+	}
+}
+
+func BenchmarkTreeViewport_Scroll20k(b *testing.B) {
+	test.NewApp()
+	c := largeTreeSetup(100, 200)
+	w := test.NewWindow(c)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 400))
+	visible := c.visibleNodes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.viewport.scrollTo(visible[(i*37)%len(visible)])
+	}
+}
@@ -16,6 +16,20 @@ func IntMax(ints ...int) int {
 	return max
 }
 
+func IntMin(ints ...int) int {
+	var min int
+	for i, num := range ints {
+		if i == 0 {
+			min = num
+			continue
+		}
+		if num < min {
+			min = num
+		}
+	}
+	return min
+}
+
 func InlineMinSize(sizes ...fyne.Size) fyne.Size {
 	var runningWidth int
 	var maxHeight int
@@ -27,6 +27,27 @@ func TestIntMax(t *testing.T) {
 	}
 }
 
+func TestIntMin(t *testing.T) {
+	tests := map[string]struct {
+		numbers  []int
+		expected int
+	}{
+		"normal numbers":   {numbers: []int{1, 2, 3, 4}, expected: 1},
+		"negative numbers": {numbers: []int{-1, -2, -3, -4}, expected: -4},
+		"mixed numbers":    {numbers: []int{1, 2, -3, -4}, expected: -4},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := IntMin(tc.numbers...)
+			want := tc.expected
+			if got != want {
+				t.Fatalf("Expected %d, but got %d", want, got)
+			}
+		})
+	}
+}
+
 func TestColumnMinSize(t *testing.T) {
 	tests := map[string]struct {
 		sizes    []fyne.Size
@@ -0,0 +1,427 @@
+package fynetree
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+	"time"
+
+	"fyne.io/fyne"
+)
+
+const (
+	// DefaultWatchDebounce is how long a FileSystemWatcher waits for changes to settle before updating the tree.
+	DefaultWatchDebounce = 100 * time.Millisecond
+	// DefaultPollInterval is how often a polling FileSystemWatcher lists the watched directories.
+	DefaultPollInterval = time.Second
+)
+
+// watchBackend reports changes to the entries of watched directories. Directories are identified by their path in the
+// watched fs.FS.
+type watchBackend interface {
+	add(dir string) error
+	remove(dir string)
+	close() error
+}
+
+// FileSystemWatcher keeps the nodes of a FileSystemModel tree up to date as files are created, deleted and renamed.
+// Only expanded directories are watched. Changes to a directory while it's condensed are applied the next time it's
+// expanded.
+type FileSystemWatcher struct {
+	root     *TreeNode
+	debounce time.Duration
+
+	mux     sync.Mutex
+	backend watchBackend
+	pending map[string]bool
+	timer   *time.Timer
+	closed  bool
+	// flushMux is held while changes are applied, so a flush started while another is running waits for it.
+	flushMux sync.Mutex
+}
+
+// WatchFileSystem watches the directories of a tree built with NewFileSystemNode, and applies the changes once
+// they've settled for the debounce duration, or DefaultWatchDebounce if it's not positive. If osDir is the directory
+// on disk that the root's fs.FS reads, such as one opened with os.DirFS, changes are reported by inotify where it's
+// available. Otherwise, including when osDir is empty, the expanded directories are polled at DefaultPollInterval, as
+// are any directories that inotify can't watch, such as when the system's watch limit is reached.
+func WatchFileSystem(root *TreeNode, osDir string, debounce time.Duration) (*FileSystemWatcher, error) {
+	w, model, err := newFileSystemWatcher(root, debounce)
+	if err != nil {
+		return nil, err
+	}
+	poll := func() watchBackend {
+		return newPollBackend(model.FS, DefaultPollInterval, w.changed)
+	}
+	if osDir != "" {
+		if notify, err := newNotifyBackend(osDir, w.changed); err == nil {
+			w.backend = &fallbackBackend{primary: notify, newFallback: poll}
+		}
+	}
+	if w.backend == nil {
+		w.backend = poll()
+	}
+	w.attach(root)
+	return w, nil
+}
+
+// WatchFileSystemPolling watches the directories of a tree built with NewFileSystemNode by listing the expanded
+// directories at the given interval, and applies the changes once they've settled for the debounce duration.
+func WatchFileSystemPolling(root *TreeNode, interval, debounce time.Duration) (*FileSystemWatcher, error) {
+	w, model, err := newFileSystemWatcher(root, debounce)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	w.backend = newPollBackend(model.FS, interval, w.changed)
+	w.attach(root)
+	return w, nil
+}
+
+// newFileSystemWatcher creates a watcher for the root without a backend.
+func newFileSystemWatcher(root *TreeNode, debounce time.Duration) (*FileSystemWatcher, *FileSystemModel, error) {
+	model, ok := root.model.(*FileSystemModel)
+	if !ok || !model.IsDir() {
+		return nil, nil, errors.New("root must be a FileSystemModel directory")
+	}
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	w := &FileSystemWatcher{
+		root:     root,
+		debounce: debounce,
+		pending:  map[string]bool{},
+	}
+	return w, model, nil
+}
+
+// Close stops watching the tree.
+func (w *FileSystemWatcher) Close() error {
+	w.mux.Lock()
+	if w.closed {
+		w.mux.Unlock()
+		return nil
+	}
+	w.closed = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mux.Unlock()
+	_ = w.root.Walk(PreOrder, func(node *TreeNode) error {
+		if model, ok := node.model.(*FileSystemModel); ok {
+			model.setWatcher(nil)
+		}
+		return nil
+	})
+	return w.backend.close()
+}
+
+// attach sets the watcher on the node's model and its listed descendants, and watches those that are expanded.
+func (w *FileSystemWatcher) attach(node *TreeNode) {
+	_ = node.Walk(PreOrder, func(node *TreeNode) error {
+		model, ok := node.model.(*FileSystemModel)
+		if !ok {
+			return SkipChildren
+		}
+		model.setWatcher(w)
+		if model.IsDir() && node.IsExpanded() {
+			model.setWatched(true)
+		}
+		return nil
+	})
+}
+
+func (w *FileSystemWatcher) watch(dir string) {
+	if err := w.backend.add(dir); err != nil {
+		fyne.LogError("Unable to watch directory", err)
+	}
+}
+
+func (w *FileSystemWatcher) unwatch(dir string) {
+	w.backend.remove(dir)
+}
+
+// changed is called by the backend when a directory's entries have changed, and updates it after the debounce.
+func (w *FileSystemWatcher) changed(dir string) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.closed {
+		return
+	}
+	w.pending[dir] = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.debounce, w.flush)
+}
+
+// flush updates every directory that changed since the last flush.
+func (w *FileSystemWatcher) flush() {
+	w.flushMux.Lock()
+	defer w.flushMux.Unlock()
+	w.mux.Lock()
+	if w.closed {
+		w.mux.Unlock()
+		return
+	}
+	pending := w.pending
+	w.pending = map[string]bool{}
+	w.mux.Unlock()
+
+	_ = w.root.Walk(PreOrder, func(node *TreeNode) error {
+		model, ok := node.model.(*FileSystemModel)
+		if !ok {
+			return SkipChildren
+		}
+		if pending[model.Path] {
+			model.sync()
+		}
+		return nil
+	})
+}
+
+// setWatcher sets the watcher that's told when this directory is expanded or condensed.
+func (m *FileSystemModel) setWatcher(w *FileSystemWatcher) {
+	m.mux.Lock()
+	m.watcher = w
+	m.watched = m.watched && w != nil
+	m.mux.Unlock()
+}
+
+func (m *FileSystemModel) getWatcher() *FileSystemWatcher {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.watcher
+}
+
+// setWatched starts or stops watching the directory when its node is expanded or condensed. It's tracked here rather
+// than read from the node, because the watcher syncs the directory from its own goroutine.
+func (m *FileSystemModel) setWatched(watched bool) {
+	m.mux.Lock()
+	w := m.watcher
+	m.watched = watched && w != nil
+	m.mux.Unlock()
+	if w == nil {
+		return
+	}
+	if watched {
+		w.watch(m.Path)
+	} else {
+		w.unwatch(m.Path)
+	}
+}
+
+// sync applies changes to the directory's entries to its children if it's expanded. A condensed directory is synced
+// when it's next expanded instead.
+func (m *FileSystemModel) sync() {
+	m.mux.Lock()
+	node, watched := m.node, m.watched
+	m.mux.Unlock()
+	if node == nil || !watched {
+		return
+	}
+	var err error
+	node.batch(func() {
+		err = m.syncEntries(node)
+	})
+	if err != nil {
+		fyne.LogError("Unable to list directory", err)
+	}
+}
+
+// syncEntries removes children for entries that are gone, and appends children for new entries, keeping the nodes
+// of unchanged entries and their state.
+func (m *FileSystemModel) syncEntries(node *TreeNode) error {
+	m.listMux.Lock()
+	defer m.listMux.Unlock()
+	entries, err := fs.ReadDir(m.FS, m.Path)
+	if err != nil {
+		return err
+	}
+	listed := make(map[string]fs.DirEntry, len(entries))
+	for _, entry := range entries {
+		listed[entry.Name()] = entry
+	}
+	for _, child := range node.Children() {
+		model, ok := child.model.(*FileSystemModel)
+		if !ok {
+			_, _ = node.Remove(child)
+			continue
+		}
+		entry, found := listed[model.Name()]
+		if found && entry.IsDir() == model.IsDir() {
+			delete(listed, model.Name())
+			child.Refresh()
+			continue
+		}
+		if _, err := node.Remove(child); err == nil {
+			unwatchTree(child)
+		}
+	}
+	for _, entry := range entries {
+		if _, added := listed[entry.Name()]; !added {
+			continue
+		}
+		if err := node.Append(m.newChild(entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unwatchTree stops watching the directories in a removed subtree.
+func unwatchTree(node *TreeNode) {
+	_ = node.Walk(PreOrder, func(node *TreeNode) error {
+		if model, ok := node.model.(*FileSystemModel); ok && model.IsDir() {
+			model.setWatched(false)
+		}
+		return nil
+	})
+}
+
+// fallbackBackend watches directories with its primary backend, and falls back to a backend created when it's first
+// needed for directories the primary can't watch.
+type fallbackBackend struct {
+	primary     watchBackend
+	newFallback func() watchBackend
+
+	mux      sync.Mutex
+	fallback watchBackend
+	fallen   map[string]bool
+}
+
+func (f *fallbackBackend) add(dir string) error {
+	err := f.primary.add(dir)
+	if err == nil {
+		return nil
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if f.fallback == nil {
+		f.fallback = f.newFallback()
+		f.fallen = map[string]bool{}
+	}
+	if err := f.fallback.add(dir); err != nil {
+		return err
+	}
+	f.fallen[dir] = true
+	return nil
+}
+
+func (f *fallbackBackend) remove(dir string) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if f.fallen[dir] {
+		f.fallback.remove(dir)
+		delete(f.fallen, dir)
+		return
+	}
+	f.primary.remove(dir)
+}
+
+func (f *fallbackBackend) close() error {
+	f.mux.Lock()
+	fallback := f.fallback
+	f.mux.Unlock()
+	if fallback != nil {
+		_ = fallback.close()
+	}
+	return f.primary.close()
+}
+
+// pollBackend lists the watched directories at an interval, and reports those whose entries changed.
+type pollBackend struct {
+	fsys     fs.FS
+	onChange func(dir string)
+	done     chan struct{}
+
+	mux      sync.Mutex
+	listings map[string]string
+}
+
+func newPollBackend(fsys fs.FS, interval time.Duration, onChange func(dir string)) *pollBackend {
+	p := &pollBackend{
+		fsys:     fsys,
+		onChange: onChange,
+		done:     make(chan struct{}),
+		listings: map[string]string{},
+	}
+	go p.run(interval)
+	return p
+}
+
+func (p *pollBackend) add(dir string) error {
+	listing, err := p.list(dir)
+	if err != nil {
+		return err
+	}
+	p.mux.Lock()
+	p.listings[dir] = listing
+	p.mux.Unlock()
+	return nil
+}
+
+func (p *pollBackend) remove(dir string) {
+	p.mux.Lock()
+	delete(p.listings, dir)
+	p.mux.Unlock()
+}
+
+func (p *pollBackend) close() error {
+	close(p.done)
+	return nil
+}
+
+func (p *pollBackend) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.poll()
+		}
+	}
+}
+
+// poll lists every watched directory, and reports those that changed since they were last listed.
+func (p *pollBackend) poll() {
+	p.mux.Lock()
+	dirs := make([]string, 0, len(p.listings))
+	for dir := range p.listings {
+		dirs = append(dirs, dir)
+	}
+	p.mux.Unlock()
+	for _, dir := range dirs {
+		listing, _ := p.list(dir)
+		p.mux.Lock()
+		previous, watched := p.listings[dir]
+		if watched {
+			p.listings[dir] = listing
+		}
+		p.mux.Unlock()
+		if watched && listing != previous {
+			p.onChange(dir)
+		}
+	}
+}
+
+// list summarises the directory's entries so changes can be detected. An unreadable directory lists as empty.
+func (p *pollBackend) list(dir string) (string, error) {
+	entries, err := fs.ReadDir(p.fsys, dir)
+	if err != nil {
+		return "", err
+	}
+	var listing []byte
+	for _, entry := range entries {
+		listing = append(listing, entry.Name()...)
+		if entry.IsDir() {
+			listing = append(listing, '/')
+		}
+		listing = append(listing, 0)
+	}
+	return string(listing), nil
+}
@@ -0,0 +1,139 @@
+//go:build linux
+// +build linux
+
+package fynetree
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// notifyMask reports changes to a directory's entries, and the directory itself going away.
+const notifyMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF |
+	unix.IN_MOVE_SELF
+
+// notifyBackend reports changes with inotify.
+type notifyBackend struct {
+	osDir    string
+	fd       int
+	onChange func(dir string)
+	done     chan struct{}
+	stopped  chan struct{}
+
+	mux     sync.Mutex
+	watches map[int]string
+	dirs    map[string]int
+}
+
+func newNotifyBackend(osDir string, onChange func(dir string)) (watchBackend, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	n := &notifyBackend{
+		osDir:    osDir,
+		fd:       fd,
+		onChange: onChange,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		watches:  map[int]string{},
+		dirs:     map[string]int{},
+	}
+	go n.run()
+	return n, nil
+}
+
+func (n *notifyBackend) add(dir string) error {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if _, watched := n.dirs[dir]; watched {
+		return nil
+	}
+	wd, err := unix.InotifyAddWatch(n.fd, filepath.Join(n.osDir, filepath.FromSlash(dir)), notifyMask)
+	if err != nil {
+		return err
+	}
+	n.watches[wd] = dir
+	n.dirs[dir] = wd
+	return nil
+}
+
+func (n *notifyBackend) remove(dir string) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if wd, watched := n.dirs[dir]; watched {
+		_, _ = unix.InotifyRmWatch(n.fd, uint32(wd))
+		delete(n.watches, wd)
+		delete(n.dirs, dir)
+	}
+}
+
+func (n *notifyBackend) close() error {
+	close(n.done)
+	<-n.stopped
+	return unix.Close(n.fd)
+}
+
+// run reads events until the backend is closed, waking periodically to check whether it has been.
+func (n *notifyBackend) run() {
+	defer close(n.stopped)
+	buf := make([]byte, 4096)
+	fds := []unix.PollFd{{Fd: int32(n.fd), Events: unix.POLLIN}}
+	for {
+		select {
+		case <-n.done:
+			return
+		default:
+		}
+		if count, err := unix.Poll(fds, 100); err != nil || count == 0 {
+			continue
+		}
+		read, err := unix.Read(n.fd, buf)
+		if err != nil || read <= 0 {
+			continue
+		}
+		n.dispatch(buf[:read])
+	}
+}
+
+// dispatch reports the directories changed by the events in the buffer.
+func (n *notifyBackend) dispatch(buf []byte) {
+	changed := map[string]bool{}
+	n.mux.Lock()
+	for offset := 0; offset+unix.SizeofInotifyEvent <= len(buf); {
+		event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+		name := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(event.Len)]
+		offset += unix.SizeofInotifyEvent + int(event.Len)
+
+		if event.Mask&unix.IN_Q_OVERFLOW != 0 {
+			// Events were dropped, so any of the watched directories may have changed.
+			for dir := range n.dirs {
+				changed[dir] = true
+			}
+			continue
+		}
+		dir, watched := n.watches[int(event.Wd)]
+		if !watched {
+			continue
+		}
+		if event.Mask&(unix.IN_DELETE_SELF|unix.IN_MOVE_SELF|unix.IN_IGNORED) != 0 {
+			// The directory itself is gone, so its parent's entries have changed.
+			delete(n.watches, int(event.Wd))
+			delete(n.dirs, dir)
+			changed[path.Dir(dir)] = true
+			continue
+		}
+		if len(bytes.TrimRight(name, "\x00")) > 0 {
+			changed[dir] = true
+		}
+	}
+	n.mux.Unlock()
+	for dir := range changed {
+		n.onChange(dir)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build linux
+// +build linux
+
+package fynetree
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestNotifyBackend_Overflow(t *testing.T) {
+	var changed []string
+	n := &notifyBackend{
+		onChange: func(dir string) {
+			changed = append(changed, dir)
+		},
+		watches: map[int]string{1: ".", 2: "src"},
+		dirs:    map[string]int{".": 1, "src": 2},
+	}
+	buf := make([]byte, unix.SizeofInotifyEvent)
+	event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[0]))
+	event.Wd = -1
+	event.Mask = unix.IN_Q_OVERFLOW
+	n.dispatch(buf)
+	sort.Strings(changed)
+	if got := strings.Join(changed, ","); got != ".,src" {
+		t.Fatalf("Expected an overflow to resync every watched directory, got %s", got)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package fynetree
+
+import "errors"
+
+// newNotifyBackend isn't supported on this platform, so watchers fall back to polling.
+func newNotifyBackend(osDir string, onChange func(dir string)) (watchBackend, error) {
+	return nil, errors.New("filesystem notifications are not supported on this platform")
+}
@@ -0,0 +1,174 @@
+package fynetree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func watchSetup(t *testing.T) (string, *TreeNode) {
+	dir := t.TempDir()
+	_ = os.Mkdir(filepath.Join(dir, "src"), 0o755)
+	_ = os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main"), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0o644)
+	root, err := NewFileSystemNode(os.DirFS(dir), ".")
+	if err != nil {
+		t.Fatalf("Failed to create root node: %v", err)
+	}
+	root.Expand()
+	return dir, root
+}
+
+// watchedChildTexts lists the children's text while the watcher may be changing them.
+func watchedChildTexts(node *TreeNode) string {
+	var texts []string
+	for _, child := range node.Children() {
+		texts = append(texts, child.GetModelText())
+	}
+	return strings.Join(texts, ",")
+}
+
+func waitForChildren(t *testing.T, node *TreeNode, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if watchedChildTexts(node) == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected children %s, got %s", want, watchedChildTexts(node))
+}
+
+func assertWatcherTracksChanges(t *testing.T, dir string, root *TreeNode) {
+	t.Helper()
+	src := root.Children()[0]
+	src.Expand()
+	src.Condense()
+
+	_ = os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644)
+	_ = os.Remove(filepath.Join(dir, "b.txt"))
+	waitForChildren(t, root, "src,a.txt")
+	_ = os.Rename(filepath.Join(dir, "a.txt"), filepath.Join(dir, "c.txt"))
+	waitForChildren(t, root, "src,c.txt")
+	if root.Children()[0] != src {
+		t.Fatalf("Expected unchanged entries to keep their nodes")
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, "src", "util.go"), []byte("package main"), 0o644)
+	time.Sleep(100 * time.Millisecond)
+	if watchedChildTexts(src) != "main.go" {
+		t.Fatalf("Expected condensed directories not to be updated, got %s", watchedChildTexts(src))
+	}
+	src.Expand()
+	if got := watchedChildTexts(src); got != "main.go,util.go" {
+		t.Fatalf("Expected changes to be applied on expand, got %s", got)
+	}
+	_ = os.Remove(filepath.Join(dir, "src", "main.go"))
+	waitForChildren(t, src, "util.go")
+}
+
+func TestWatchFileSystemPolling(t *testing.T) {
+	dir, root := watchSetup(t)
+	if _, err := WatchFileSystemPolling(root.Children()[1], 0, 0); err == nil {
+		t.Fatalf("Expected error watching a file")
+	}
+	w, err := WatchFileSystemPolling(root, 10*time.Millisecond, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to watch: %v", err)
+	}
+	defer w.Close()
+	assertWatcherTracksChanges(t, dir, root)
+}
+
+func TestWatchFileSystem(t *testing.T) {
+	dir, root := watchSetup(t)
+	w, err := WatchFileSystem(root, dir, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to watch: %v", err)
+	}
+	if w.debounce != 20*time.Millisecond {
+		t.Fatalf("Expected the debounce to be used, got %v", w.debounce)
+	}
+	assertWatcherTracksChanges(t, dir, root)
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close watcher: %v", err)
+	}
+	_ = os.WriteFile(filepath.Join(dir, "d.txt"), []byte("d"), 0o644)
+	time.Sleep(2 * DefaultWatchDebounce)
+	if got := watchedChildTexts(root); got != "src,c.txt" {
+		t.Fatalf("Expected no changes after closing, got %s", got)
+	}
+}
+
+// failingBackend fails to watch the given directory, like inotify does when the watch limit is reached.
+type failingBackend struct {
+	fail    string
+	mux     sync.Mutex
+	watched map[string]bool
+	closed  bool
+}
+
+func (f *failingBackend) add(dir string) error {
+	if dir == f.fail {
+		return errors.New("no space left on device")
+	}
+	f.mux.Lock()
+	f.watched[dir] = true
+	f.mux.Unlock()
+	return nil
+}
+
+func (f *failingBackend) remove(dir string) {
+	f.mux.Lock()
+	delete(f.watched, dir)
+	f.mux.Unlock()
+}
+
+func (f *failingBackend) close() error {
+	f.closed = true
+	return nil
+}
+
+func TestFileSystemWatcher_FallsBackToPolling(t *testing.T) {
+	dir, root := watchSetup(t)
+	w, model, err := newFileSystemWatcher(root, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to create watcher: %v", err)
+	}
+	primary := &failingBackend{fail: "src", watched: map[string]bool{}}
+	var poll *pollBackend
+	w.backend = &fallbackBackend{primary: primary, newFallback: func() watchBackend {
+		poll = newPollBackend(model.FS, 10*time.Millisecond, w.changed)
+		return poll
+	}}
+	w.attach(root)
+	if !primary.watched["."] || poll != nil {
+		t.Fatalf("Expected directories to be watched by the primary backend")
+	}
+
+	src := root.Children()[0]
+	src.Expand()
+	if primary.watched["src"] || poll == nil {
+		t.Fatalf("Expected a directory the primary can't watch to be polled")
+	}
+	_ = os.WriteFile(filepath.Join(dir, "src", "util.go"), []byte("package main"), 0o644)
+	waitForChildren(t, src, "main.go,util.go")
+
+	src.Condense()
+	poll.mux.Lock()
+	_, polled := poll.listings["src"]
+	poll.mux.Unlock()
+	if polled {
+		t.Fatalf("Expected a condensed directory to stop being polled")
+	}
+	_ = w.Close()
+	if !primary.closed {
+		t.Fatalf("Expected closing the watcher to close its backends")
+	}
+}